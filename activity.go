@@ -0,0 +1,273 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/tableprinter"
+	"github.com/shurcooL/githubv4"
+	"github.com/spf13/cobra"
+)
+
+var activityFields = []string{
+	"action",
+	"timestamp",
+	"sponsor",
+	"tier",
+}
+
+var activityPeriods = []string{
+	"day",
+	"week",
+	"month",
+	"all",
+}
+
+var activityActions = []string{
+	"NEW_SPONSORSHIP",
+	"CANCELLED_SPONSORSHIP",
+	"TIER_CHANGE",
+	"REFUND",
+	"PENDING_CHANGE",
+	"SPONSOR_MATCH_DISABLED",
+}
+
+type ActivityOptions struct {
+	Client   *api.GraphQLClient
+	IOs      Terminal
+	Prompter Prompter
+
+	Username  string
+	FieldsRaw string
+	Fields    []string
+	Period    string
+	Limit     int
+
+	// ActionsRaw and Actions hold the activity actions selected with --action.
+	ActionsRaw string
+	Actions    []string
+	Quiet      bool
+}
+
+func NewCmdActivity(
+	client clientFunc,
+	ios Terminal,
+	prompter Prompter,
+	runF func(*ActivityOptions) error,
+) *cobra.Command {
+	opts := &ActivityOptions{
+		IOs:      ios,
+		Prompter: prompter,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "activity [<user>]",
+		Short: "List sponsorship activity",
+		Long: `List recent sponsorship activity of a given user or organization, such as new
+sponsorships, cancellations and tier changes.
+
+Sponsorship activity is only visible to the sponsored account and its admins.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 1 {
+				return errors.New("too many arguments")
+			} else if len(args) == 1 {
+				opts.Username = args[0]
+			}
+
+			if err := validateLimit(opts.Limit); err != nil {
+				return err
+			}
+
+			if !slices.Contains(activityPeriods, opts.Period) {
+				return fmt.Errorf("unknown period: %q (available values: %s)", opts.Period, strings.Join(activityPeriods, ", "))
+			}
+
+			if opts.ActionsRaw != "" {
+				actions := strings.Split(opts.ActionsRaw, ",")
+				for _, a := range actions {
+					if !slices.Contains(activityActions, a) {
+						return fmt.Errorf("unknown action: %q (available actions: %s)", a, strings.Join(activityActions, ", "))
+					}
+				}
+				opts.Actions = actions
+			}
+
+			fields, err := parseFieldsOf(opts.FieldsRaw, activityFields)
+			if err != nil {
+				return err
+			}
+			opts.Fields = fields
+
+			opts.Quiet = isQuiet(cmd)
+
+			if runF != nil {
+				return runF(opts)
+			}
+
+			c, err := client()
+			if err != nil {
+				return err
+			}
+			opts.Client = c
+
+			return activityRun(opts)
+		},
+	}
+
+	// We can't use StringSliceVar method since it supports multiple assignments
+	// like: --json a,b --json c
+	cmd.Flags().StringVar(&opts.FieldsRaw, "json", "", "JSON fields")
+	cmd.Flags().StringVar(&opts.Period, "period", "month", fmt.Sprintf("Time period of the activity: {%s}", strings.Join(activityPeriods, "|")))
+	cmd.Flags().IntVarP(&opts.Limit, "limit", "L", 0, fmt.Sprintf("Maximum number of activities to fetch (default %d)", defaultListLimit))
+	cmd.Flags().StringVar(&opts.ActionsRaw, "action", "", fmt.Sprintf("Only list activities of the given comma-separated actions: {%s}", strings.Join(activityActions, "|")))
+
+	return cmd
+}
+
+func activityRun(opts *ActivityOptions) error {
+	username, err := resolveUsername(opts.Client, opts.IOs, opts.Prompter, opts.Username)
+	if err != nil {
+		return err
+	}
+
+	limit := opts.Limit
+	if limit == 0 {
+		limit = defaultListLimit
+	}
+
+	activities, err := listActivities(opts.Client, username, githubv4.SponsorsActivityPeriod(strings.ToUpper(opts.Period)), opts.Actions, limit)
+	if err != nil {
+		return err
+	}
+
+	if opts.Fields != nil {
+		data := make([]any, 0, len(activities))
+		for _, a := range activities {
+			m := make(map[string]any, len(opts.Fields))
+			for _, f := range opts.Fields {
+				switch f {
+				case "action":
+					m["action"] = a.Action
+				case "timestamp":
+					m["timestamp"] = a.Timestamp.Format(time.RFC3339)
+				case "sponsor":
+					m["sponsor"] = a.Sponsor
+				case "tier":
+					m["tier"] = a.Tier
+				}
+			}
+			data = append(data, m)
+		}
+
+		return printJSON(opts.IOs, data, opts.IOs.IsTerminalOutput())
+	}
+
+	if len(activities) == 0 {
+		if opts.IOs.IsTerminalOutput() && !opts.Quiet {
+			fmt.Fprintln(opts.IOs.ErrOut(), "no activity found")
+		}
+		return nil
+	}
+
+	width, _, _ := opts.IOs.Size()
+	table := tableprinter.New(opts.IOs.Out(), opts.IOs.IsTerminalOutput(), width)
+	table.AddHeader([]string{"ACTION", "WHEN", "SPONSOR", "TIER"})
+	for _, a := range activities {
+		table.AddField(a.Action)
+		if opts.IOs.IsTerminalOutput() {
+			table.AddField(a.Timestamp.Format(time.DateOnly))
+		} else {
+			table.AddField(a.Timestamp.Format(time.RFC3339))
+		}
+		table.AddField(a.Sponsor)
+		table.AddField(a.Tier)
+		table.EndRow()
+	}
+
+	return table.Render()
+}
+
+type activity struct {
+	// Action is the kind of activity, e.g. "NEW_SPONSORSHIP" or "TIER_CHANGE".
+	Action    string
+	Timestamp time.Time
+	Sponsor   string
+	Tier      string
+}
+
+// listActivities fetches the latest sponsorship activities of the given user
+// or organization within the given period, up to limit activities. If actions
+// is not empty, only activities of those actions are fetched.
+func listActivities(client *api.GraphQLClient, username string, period githubv4.SponsorsActivityPeriod, actions []string, limit int) ([]activity, error) {
+	type actor struct {
+		Login githubv4.String
+	}
+
+	var query struct {
+		RepositoryOwner *struct {
+			Sponsorable struct {
+				SponsorsActivities struct {
+					Nodes []struct {
+						Action       githubv4.SponsorsActivityAction
+						Timestamp    githubv4.DateTime
+						SponsorsTier *struct {
+							Name githubv4.String
+						}
+						Sponsor *struct {
+							User actor `graphql:"... on User"`
+							Org  actor `graphql:"... on Organization"`
+						}
+					}
+				} `graphql:"sponsorsActivities(first: $first, period: $period, actions: $actions)"`
+			} `graphql:"... on Sponsorable"`
+		} `graphql:"repositoryOwner(login: $login)"`
+	}
+
+	// A null actions argument means all actions.
+	var actionsVar *[]githubv4.SponsorsActivityAction
+	if len(actions) > 0 {
+		values := make([]githubv4.SponsorsActivityAction, 0, len(actions))
+		for _, a := range actions {
+			values = append(values, githubv4.SponsorsActivityAction(a))
+		}
+		actionsVar = &values
+	}
+
+	variables := map[string]any{
+		"login":   githubv4.String(username),
+		"first":   githubv4.Int(limit),
+		"period":  period,
+		"actions": actionsVar,
+	}
+
+	if err := client.Query("SponsorActivityList", &query, variables); err != nil {
+		return nil, fmt.Errorf("failed to list sponsors activity for %q: %w", username, translateQueryError(err))
+	}
+	if query.RepositoryOwner == nil {
+		return nil, userNotFoundError(username)
+	}
+
+	nodes := query.RepositoryOwner.Sponsorable.SponsorsActivities.Nodes
+	result := make([]activity, 0, len(nodes))
+	for _, node := range nodes {
+		a := activity{
+			Action:    string(node.Action),
+			Timestamp: node.Timestamp.Time,
+		}
+		if node.SponsorsTier != nil {
+			a.Tier = string(node.SponsorsTier.Name)
+		}
+		if node.Sponsor != nil {
+			a.Sponsor = string(node.Sponsor.User.Login)
+			if a.Sponsor == "" {
+				a.Sponsor = string(node.Sponsor.Org.Login)
+			}
+		}
+		result = append(result, a)
+	}
+	return result, nil
+}
@@ -0,0 +1,402 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/prompter"
+	"github.com/google/shlex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCmdActivity(t *testing.T) {
+	tests := []struct {
+		name    string
+		cli     string
+		wants   ActivityOptions
+		wantErr string
+	}{
+		{
+			name: "no arg",
+			cli:  "",
+			wants: ActivityOptions{
+				Username: "",
+				Period:   "month",
+			},
+		}, {
+			name: "normal",
+			cli:  "johndoe",
+			wants: ActivityOptions{
+				Username: "johndoe",
+				Period:   "month",
+			},
+		}, {
+			name: "normal period",
+			cli:  "--period week johndoe",
+			wants: ActivityOptions{
+				Username: "johndoe",
+				Period:   "week",
+			},
+		}, {
+			name: "normal limit",
+			cli:  "-L 10 johndoe",
+			wants: ActivityOptions{
+				Username: "johndoe",
+				Period:   "month",
+				Limit:    10,
+			},
+		}, {
+			name: "normal json",
+			cli:  "--json action,timestamp,sponsor,tier johndoe",
+			wants: ActivityOptions{
+				Username: "johndoe",
+				Period:   "month",
+				Fields:   []string{"action", "timestamp", "sponsor", "tier"},
+			},
+		}, {
+			name: "json all",
+			cli:  "--json all johndoe",
+			wants: ActivityOptions{
+				Username: "johndoe",
+				Period:   "month",
+				Fields:   []string{"action", "timestamp", "sponsor", "tier"},
+			},
+		}, {
+			name: "json trimmed and deduplicated",
+			cli:  "--json 'action, sponsor,,action' johndoe",
+			wants: ActivityOptions{
+				Username: "johndoe",
+				Period:   "month",
+				Fields:   []string{"action", "sponsor"},
+			},
+		}, {
+			name: "normal action",
+			cli:  "--action NEW_SPONSORSHIP,CANCELLED_SPONSORSHIP johndoe",
+			wants: ActivityOptions{
+				Username: "johndoe",
+				Period:   "month",
+				Actions:  []string{"NEW_SPONSORSHIP", "CANCELLED_SPONSORSHIP"},
+			},
+		}, {
+			name:    "failure action",
+			cli:     "--action NEW_SPONSORSHIP,CANCELED johndoe",
+			wantErr: "unknown action: \"CANCELED\" (available actions: NEW_SPONSORSHIP, CANCELLED_SPONSORSHIP, TIER_CHANGE, REFUND, PENDING_CHANGE, SPONSOR_MATCH_DISABLED)",
+		}, {
+			name:    "failure too many arguments",
+			cli:     "johndoe janedoe",
+			wantErr: "too many arguments",
+		}, {
+			name:    "failure period",
+			cli:     "--period year johndoe",
+			wantErr: "unknown period: \"year\" (available values: day, week, month, all)",
+		}, {
+			name:    "failure limit",
+			cli:     "-L 101 johndoe",
+			wantErr: "invalid limit: 101 (must not exceed 100)",
+		}, {
+			name:    "failure json",
+			cli:     "--json login johndoe",
+			wantErr: "unknown JSON field: \"login\" (available fields: action, timestamp, sponsor, tier)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			argv, err := shlex.Split(tt.cli)
+			assert.NoError(t, err)
+
+			var activityOpts *ActivityOptions
+			cmd := NewCmdActivity(
+				nil, nil, nil,
+				func(opts *ActivityOptions) error {
+					activityOpts = opts
+					return nil
+				},
+			)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			_, err = cmd.ExecuteC()
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			require.Equal(t, tt.wants.Username, activityOpts.Username)
+			require.Equal(t, tt.wants.Period, activityOpts.Period)
+			require.Equal(t, tt.wants.Limit, activityOpts.Limit)
+			require.Equal(t, tt.wants.Actions, activityOpts.Actions)
+			require.Equal(t, tt.wants.Fields, activityOpts.Fields)
+		})
+	}
+}
+
+func Test_activityRun(t *testing.T) {
+	defaultHTTPStubs := func(t *testing.T, mt *mockTransport) {
+		mt.respBody = `
+				{
+					"data": {
+						"repositoryOwner": {
+							"sponsorsActivities": {
+								"nodes": [
+									{
+										"action": "NEW_SPONSORSHIP",
+										"timestamp": "2024-03-01T10:00:00Z",
+										"sponsorsTier": {"name": "$5 a month"},
+										"sponsor": {"login": "foo"}
+									},
+									{
+										"action": "CANCELLED_SPONSORSHIP",
+										"timestamp": "2024-02-15T08:30:00Z",
+										"sponsorsTier": {"name": "$10 a month"},
+										"sponsor": {"login": "bar"}
+									}
+								]
+							}
+						}
+					}
+				}`
+	}
+
+	tests := []struct {
+		name          string
+		tty           bool
+		opts          *ActivityOptions
+		httpStubs     func(*testing.T, *mockTransport)
+		prompterStubs func(*testing.T, *prompter.PrompterMock)
+		wantStdout    []string
+		wantStderr    string
+		wantErr       string
+	}{
+		{
+			name: "normal tty",
+			tty:  true,
+			opts: &ActivityOptions{
+				Username: "johndoe",
+				Period:   "month",
+			},
+			httpStubs: defaultHTTPStubs,
+			wantStdout: []string{
+				"ACTION                 WHEN        SPONSOR  TIER",
+				"NEW_SPONSORSHIP        2024-03-01  foo      $5 a month",
+				"CANCELLED_SPONSORSHIP  2024-02-15  bar      $10 a month",
+			},
+		}, {
+			name:      "normal tty, no-username",
+			tty:       true,
+			opts:      &ActivityOptions{Period: "month"},
+			httpStubs: defaultHTTPStubs,
+			prompterStubs: func(t *testing.T, pm *prompter.PrompterMock) {
+				pm.RegisterInput("Which user do you want to target?", func(_, def string) (string, error) {
+					assert.Empty(t, def)
+					return "johndoe", nil
+				})
+			},
+			wantStdout: []string{
+				"ACTION                 WHEN        SPONSOR  TIER",
+				"NEW_SPONSORSHIP        2024-03-01  foo      $5 a month",
+				"CANCELLED_SPONSORSHIP  2024-02-15  bar      $10 a month",
+			},
+		}, {
+			name: "normal no-tty",
+			tty:  false,
+			opts: &ActivityOptions{
+				Username: "johndoe",
+				Period:   "month",
+			},
+			httpStubs: defaultHTTPStubs,
+			wantStdout: []string{
+				"NEW_SPONSORSHIP\t2024-03-01T10:00:00Z\tfoo\t$5 a month",
+				"CANCELLED_SPONSORSHIP\t2024-02-15T08:30:00Z\tbar\t$10 a month",
+			},
+		}, {
+			name: "normal json",
+			tty:  false,
+			opts: &ActivityOptions{
+				Username: "johndoe",
+				Period:   "month",
+				Fields:   activityFields,
+			},
+			httpStubs:  defaultHTTPStubs,
+			wantStdout: []string{`[{"action":"NEW_SPONSORSHIP","sponsor":"foo","tier":"$5 a month","timestamp":"2024-03-01T10:00:00Z"},{"action":"CANCELLED_SPONSORSHIP","sponsor":"bar","tier":"$10 a month","timestamp":"2024-02-15T08:30:00Z"}]`},
+		}, {
+			name: "normal tty, no activity",
+			tty:  true,
+			opts: &ActivityOptions{
+				Username: "johndoe",
+				Period:   "month",
+			},
+			httpStubs: func(_ *testing.T, mt *mockTransport) {
+				mt.respBody = `{"data":{"repositoryOwner":{"sponsorsActivities":{"nodes":[]}}}}`
+			},
+			wantStderr: "no activity found\n",
+		}, {
+			name: "normal no-tty, no activity",
+			tty:  false,
+			opts: &ActivityOptions{
+				Username: "johndoe",
+				Period:   "month",
+			},
+			httpStubs: func(_ *testing.T, mt *mockTransport) {
+				mt.respBody = `{"data":{"repositoryOwner":{"sponsorsActivities":{"nodes":[]}}}}`
+			},
+		}, {
+			name: "failure unknown account",
+			tty:  true,
+			opts: &ActivityOptions{
+				Username: "johndoe",
+				Period:   "month",
+			},
+			httpStubs: func(_ *testing.T, mt *mockTransport) {
+				mt.respBody = `{"data":{"repositoryOwner":null}}`
+			},
+			wantErr: "no such user: \"johndoe\"",
+		}, {
+			name:    "failure no-tty, no-username",
+			tty:     false,
+			opts:    &ActivityOptions{Period: "month"},
+			wantErr: "username not provided",
+		}, {
+			name: "api error",
+			tty:  true,
+			opts: &ActivityOptions{
+				Username: "johndoe",
+				Period:   "month",
+			},
+			httpStubs: func(_ *testing.T, mt *mockTransport) {
+				mt.respBody = `{"data":{}, "errors": [{"message": "some gql error"}]}`
+			},
+			wantErr: "failed to list sponsors activity for \"johndoe\": GraphQL: some gql error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTransport := &mockTransport{}
+			client, err := api.NewGraphQLClient(api.ClientOptions{
+				Host:      "foo",
+				AuthToken: "bar",
+				Transport: mockTransport,
+			})
+			require.NoError(t, err)
+
+			pm := &prompter.PrompterMock{}
+			if tt.prompterStubs != nil {
+				tt.prompterStubs(t, pm)
+			}
+			tt.opts.Prompter = pm
+
+			ios := &mockTerminal{
+				width:  999,
+				height: 999,
+			}
+			ios.isTTY = tt.tty
+
+			tt.opts.IOs = ios
+			tt.opts.Client = client
+
+			if tt.httpStubs != nil {
+				tt.httpStubs(t, mockTransport)
+			}
+
+			err = activityRun(tt.opts)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			expectedStdout := ""
+			if len(tt.wantStdout) > 0 {
+				expectedStdout = fmt.Sprintf("%s\n", strings.Join(tt.wantStdout, "\n"))
+			}
+			assert.Equal(t, expectedStdout, ios.stdout.String())
+			assert.Equal(t, tt.wantStderr, ios.stderr.String())
+		})
+	}
+}
+
+func Test_activityRun_variables(t *testing.T) {
+	tests := []struct {
+		name        string
+		period      string
+		limit       int
+		actions     []string
+		wantPeriod  string
+		wantFirst   int
+		wantActions string
+	}{
+		{
+			name:        "default",
+			period:      "month",
+			wantPeriod:  "MONTH",
+			wantFirst:   defaultListLimit,
+			wantActions: "null",
+		}, {
+			name:        "week with limit",
+			period:      "week",
+			limit:       5,
+			wantPeriod:  "WEEK",
+			wantFirst:   5,
+			wantActions: "null",
+		}, {
+			name:        "all",
+			period:      "all",
+			wantPeriod:  "ALL",
+			wantFirst:   defaultListLimit,
+			wantActions: "null",
+		}, {
+			name:        "actions",
+			period:      "month",
+			actions:     []string{"CANCELLED_SPONSORSHIP", "PENDING_CHANGE"},
+			wantPeriod:  "MONTH",
+			wantFirst:   defaultListLimit,
+			wantActions: `["CANCELLED_SPONSORSHIP","PENDING_CHANGE"]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTransport := &mockTransport{
+				respBody: `{"data":{"repositoryOwner":{"sponsorsActivities":{"nodes":[]}}}}`,
+			}
+			client, err := api.NewGraphQLClient(api.ClientOptions{
+				Host:      "foo",
+				AuthToken: "bar",
+				Transport: mockTransport,
+			})
+			require.NoError(t, err)
+
+			opts := &ActivityOptions{
+				Client:   client,
+				IOs:      &mockTerminal{},
+				Username: "johndoe",
+				Period:   tt.period,
+				Limit:    tt.limit,
+				Actions:  tt.actions,
+			}
+			require.NoError(t, activityRun(opts))
+
+			require.Len(t, mockTransport.reqBodies, 1)
+			var req struct {
+				Variables struct {
+					Period  string          `json:"period"`
+					First   int             `json:"first"`
+					Actions json.RawMessage `json:"actions"`
+				} `json:"variables"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(mockTransport.reqBodies[0]), &req))
+			assert.Equal(t, tt.wantPeriod, req.Variables.Period)
+			assert.Equal(t, tt.wantFirst, req.Variables.First)
+			assert.JSONEq(t, tt.wantActions, string(req.Variables.Actions))
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/tableprinter"
+)
+
+// batchResult holds the sponsors listed for one of the accounts of a batch.
+type batchResult struct {
+	Username string
+	Sponsors []sponsor
+}
+
+// canListBatch reports whether the list options allow listing the sponsors of
+// several accounts.
+func canListBatch(opts *ListOptions) bool {
+	return !(opts.Me || opts.Web || opts.Count || opts.Total || opts.Watch || opts.CSV || opts.TSV || opts.Template != "" || opts.JQ != "" || opts.NDJSON)
+}
+
+// stdinPiped reports whether the given standard input is a pipe or a non-empty
+// file, rather than a terminal. It is replaced in tests.
+var stdinPiped = func(in io.Reader) bool {
+	f, ok := in.(*os.File)
+	if !ok {
+		return false
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeNamedPipe != 0 || (fi.Mode().IsRegular() && fi.Size() > 0)
+}
+
+// readUsernames reads newline-separated usernames, skipping blank lines and
+// lines starting with "#".
+func readUsernames(r io.Reader) ([]string, error) {
+	var usernames []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		usernames = append(usernames, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read usernames: %w", err)
+	}
+	return usernames, nil
+}
+
+// listBatchRun lists the sponsors of each of the given accounts. An account
+// that fails is reported on stderr without aborting the batch, unless
+// --fail-fast is set.
+func listBatchRun(ctx context.Context, opts *ListOptions, usernames []string) error {
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	results := make([]batchResult, 0, len(usernames))
+	failed := 0
+	for _, username := range usernames {
+		sponsors, _, err := fetchSponsors(ctx, opts, username)
+		if err != nil {
+			if opts.FailFast {
+				return fmt.Errorf("%s: %w", username, err)
+			}
+			fmt.Fprintf(opts.IOs.ErrOut(), "%s: %s\n", username, err)
+			failed++
+			continue
+		}
+		results = append(results, batchResult{Username: username, Sponsors: sponsors})
+	}
+
+	if failed == 0 && opts.ExitStatus && !slices.ContainsFunc(results, func(r batchResult) bool { return len(r.Sponsors) > 0 }) {
+		return errSilent
+	}
+
+	if err := printSponsorsBatch(opts.IOs, results, opts.Fields, opts.Columns, prettyJSON(opts.IOs, opts.Pretty, opts.Compact), opts.NoHeader, opts.Quiet); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to list sponsors of %d of %d accounts", failed, len(usernames))
+	}
+	return nil
+}
+
+// printSponsorsBatch prints the sponsors of several accounts, either as a JSON
+// object keyed by username or as a table with a leading TARGET column.
+func printSponsorsBatch(ios Terminal, results []batchResult, fields, columns []string, pretty, noHeader, quiet bool) error {
+	if fields != nil {
+		data := make(map[string]any, len(results))
+		for _, r := range results {
+			data[r.Username] = sponsorsData(r.Sponsors, fields)
+		}
+		return printJSON(ios, data, pretty)
+	}
+
+	var all []sponsor
+	for _, r := range results {
+		all = append(all, r.Sponsors...)
+	}
+
+	if len(all) == 0 {
+		if ios.IsTerminalOutput() && !quiet {
+			fmt.Fprintln(ios.ErrOut(), "no sponsor found")
+		}
+		return nil
+	}
+
+	if columns == nil {
+		columns = defaultColumns(all, ios.IsTerminalOutput())
+	}
+
+	width, _, _ := ios.Size()
+	headers := []string{"TARGET"}
+	for _, c := range columns {
+		if c == "login" {
+			headers = append(headers, "SPONSOR")
+		} else {
+			headers = append(headers, listColumnHeaders[c])
+		}
+	}
+	table := tableprinter.New(ios.Out(), ios.IsTerminalOutput(), width)
+	if !noHeader {
+		table.AddHeader(headers)
+	}
+	for _, r := range results {
+		for _, sponsor := range r.Sponsors {
+			table.AddField(r.Username)
+			for _, c := range columns {
+				addSponsorField(table, ios, sponsor, c)
+			}
+			table.EndRow()
+		}
+	}
+
+	return table.Render()
+}
@@ -0,0 +1,283 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/prompter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_readUsernames(t *testing.T) {
+	usernames, err := readUsernames(strings.NewReader("alice\n\n  # a comment\n bob \n#carol\ndave"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice", "bob", "dave"}, usernames)
+}
+
+func Test_listRun_stdin(t *testing.T) {
+	notFound := `{"data":{"repositoryOwner":null}}`
+
+	tests := []struct {
+		name       string
+		tty        bool
+		opts       *ListOptions
+		stdin      string
+		respBodies []string
+		wantStdout []string
+		wantStderr string
+		wantErr    string
+	}{
+		{
+			name:  "tty",
+			tty:   true,
+			opts:  &ListOptions{},
+			stdin: "alice\n# skipped\n\nbob\n",
+			respBodies: []string{
+				sponsorsPage(2, "", false, userEdge("foo", `"name":"FOO"`), userEdge("bar", `"name":"BAR"`)),
+				sponsorsPage(1, "", false, userEdge("baz", `"name":"BAZ"`)),
+			},
+			wantStdout: []string{
+				"TARGET  SPONSOR  NAME",
+				"alice   foo      FOO",
+				"alice   bar      BAR",
+				"bob     baz      BAZ",
+			},
+		}, {
+			name:       "no-tty",
+			tty:        false,
+			opts:       &ListOptions{},
+			stdin:      "alice\nbob\n",
+			respBodies: []string{sponsorsPage(1, "", false, userEdge("foo")), sponsorsPage(1, "", false, userEdge("baz"))},
+			wantStdout: []string{
+				"alice\tfoo",
+				"bob\tbaz",
+			},
+		}, {
+			name:       "json",
+			tty:        false,
+			opts:       &ListOptions{Fields: []string{"login"}},
+			stdin:      "alice\nbob\n",
+			respBodies: []string{sponsorsPage(1, "", false, userEdge("foo")), sponsorsPage(0, "", false)},
+			wantStdout: []string{`{"alice":[{"login":"foo"}],"bob":[]}`},
+		}, {
+			name:       "failing account does not abort the batch",
+			tty:        false,
+			opts:       &ListOptions{},
+			stdin:      "alice\nbob\ncarol\n",
+			respBodies: []string{sponsorsPage(1, "", false, userEdge("foo")), notFound, sponsorsPage(1, "", false, userEdge("baz"))},
+			wantStdout: []string{
+				"alice\tfoo",
+				"carol\tbaz",
+			},
+			wantStderr: "bob: no such user: \"bob\"\n",
+			wantErr:    "failed to list sponsors of 1 of 3 accounts",
+		}, {
+			name:       "fail fast",
+			tty:        false,
+			opts:       &ListOptions{FailFast: true},
+			stdin:      "alice\nbob\ncarol\n",
+			respBodies: []string{sponsorsPage(1, "", false, userEdge("foo")), notFound, sponsorsPage(1, "", false, userEdge("baz"))},
+			wantErr:    "bob: no such user: \"bob\"",
+		}, {
+			name:       "no sponsors tty",
+			tty:        true,
+			opts:       &ListOptions{},
+			stdin:      "alice\n",
+			respBodies: []string{sponsorsPage(0, "", false)},
+			wantStderr: "no sponsor found\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTransport := &mockTransport{respBodies: tt.respBodies}
+			client, err := api.NewGraphQLClient(api.ClientOptions{
+				Host:      "foo",
+				AuthToken: "bar",
+				Transport: mockTransport,
+			})
+			require.NoError(t, err)
+
+			ios := &mockTerminal{
+				width:  999,
+				height: 999,
+				isTTY:  tt.tty,
+			}
+			ios.stdin.WriteString(tt.stdin)
+
+			tt.opts.Client = client
+			tt.opts.IOs = ios
+			tt.opts.Prompter = &prompter.PrompterMock{}
+			tt.opts.Stdin = true
+
+			err = listRun(context.Background(), tt.opts)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			expectedStdout := ""
+			if len(tt.wantStdout) > 0 {
+				expectedStdout = fmt.Sprintf("%s\n", strings.Join(tt.wantStdout, "\n"))
+			}
+			assert.Equal(t, expectedStdout, ios.stdout.String())
+			assert.Equal(t, tt.wantStderr, ios.stderr.String())
+		})
+	}
+}
+
+func Test_listRun_pipedStdin(t *testing.T) {
+	origStdinPiped := stdinPiped
+	stdinPiped = func(io.Reader) bool { return true }
+	t.Cleanup(func() { stdinPiped = origStdinPiped })
+
+	tests := []struct {
+		name       string
+		tty        bool
+		opts       *ListOptions
+		stdin      string
+		respBodies []string
+		wantStdout []string
+		wantErr    string
+	}{
+		{
+			name:  "usernames",
+			tty:   true,
+			opts:  &ListOptions{},
+			stdin: "alice  \n\n  bob\n",
+			respBodies: []string{
+				sponsorsPage(1, "", false, userEdge("foo", `"name":"FOO"`)),
+				sponsorsPage(1, "", false, userEdge("baz", `"name":"BAZ"`)),
+			},
+			wantStdout: []string{
+				"TARGET  SPONSOR  NAME",
+				"alice   foo      FOO",
+				"bob     baz      BAZ",
+			},
+		}, {
+			name:       "json",
+			tty:        true,
+			opts:       &ListOptions{Fields: []string{"login"}, Compact: true},
+			stdin:      "alice\nbob\n",
+			respBodies: []string{sponsorsPage(1, "", false, userEdge("foo")), sponsorsPage(0, "", false)},
+			wantStdout: []string{`{"alice":[{"login":"foo"}],"bob":[]}`},
+		}, {
+			name:       "username argument takes precedence",
+			tty:        true,
+			opts:       &ListOptions{Username: "johndoe", Fields: []string{"login"}, Compact: true},
+			stdin:      "alice\nbob\n",
+			respBodies: []string{sponsorsPage(1, "", false, userEdge("foo"))},
+			wantStdout: []string{`[{"login":"foo"}]`},
+		}, {
+			name:    "no usernames",
+			tty:     true,
+			opts:    &ListOptions{},
+			stdin:   "\n  \n",
+			wantErr: "no username entered",
+		}, {
+			name:    "not with single account flags",
+			tty:     true,
+			opts:    &ListOptions{Count: true},
+			stdin:   "alice\n",
+			wantErr: "no username entered",
+		}, {
+			name:    "not read off a terminal",
+			tty:     false,
+			opts:    &ListOptions{},
+			stdin:   "alice\n",
+			wantErr: "username not provided",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := api.NewGraphQLClient(api.ClientOptions{
+				Host:      "foo",
+				AuthToken: "bar",
+				Transport: &mockTransport{respBodies: tt.respBodies},
+			})
+			require.NoError(t, err)
+
+			ios := &mockTerminal{width: 999, height: 999, isTTY: tt.tty}
+			ios.stdin.WriteString(tt.stdin)
+
+			pm := &prompter.PrompterMock{}
+			pm.RegisterInput("Which user do you want to target?", func(_, _ string) (string, error) {
+				return "", errors.New("no username entered")
+			})
+
+			tt.opts.Client = client
+			tt.opts.IOs = ios
+			tt.opts.Prompter = pm
+
+			err = listRun(context.Background(), tt.opts)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, strings.Join(tt.wantStdout, "\n")+"\n", ios.stdout.String())
+		})
+	}
+}
+
+func Test_stdinPiped(t *testing.T) {
+	dir := t.TempDir()
+
+	empty, err := os.Create(filepath.Join(dir, "empty"))
+	require.NoError(t, err)
+	defer empty.Close()
+	assert.False(t, stdinPiped(empty))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "usernames"), []byte("alice\n"), 0o644))
+	file, err := os.Open(filepath.Join(dir, "usernames"))
+	require.NoError(t, err)
+	defer file.Close()
+	assert.True(t, stdinPiped(file))
+
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	defer r.Close()
+	defer w.Close()
+	assert.True(t, stdinPiped(r))
+
+	assert.False(t, stdinPiped(strings.NewReader("alice\n")))
+}
+
+func Test_listRun_multipleUsernames(t *testing.T) {
+	mockTransport := &mockTransport{
+		respBodies: []string{
+			`{"data":{"repositoryOwner":{"sponsors":{"edges":[{"node":{"__typename":"User","login":"foo","name":"Foo"}}],"totalCount":1}}}}`,
+			`{"data":{"repositoryOwner":{"sponsors":{"edges":[{"node":{"__typename":"User","login":"bar","name":"Bar"}}],"totalCount":1}}}}`,
+		},
+	}
+	client, err := api.NewGraphQLClient(api.ClientOptions{
+		Host:      "foo",
+		AuthToken: "bar",
+		Transport: mockTransport,
+	})
+	require.NoError(t, err)
+
+	ios := &mockTerminal{}
+	opts := &ListOptions{
+		Client:    client,
+		IOs:       ios,
+		Prompter:  &prompter.PrompterMock{},
+		Usernames: []string{"alice", "bob"},
+		Fields:    []string{"login", "name"},
+	}
+	require.NoError(t, listRun(context.Background(), opts))
+
+	assert.Equal(t, `{"alice":[{"login":"foo","name":"Foo"}],"bob":[{"login":"bar","name":"Bar"}]}`+"\n", ios.stdout.String())
+	assert.Empty(t, ios.stderr.String())
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+var breakdownFields = []string{
+	"tier",
+	"count",
+	"totalMonthly",
+}
+
+// noTierName is the name of the group of sponsors whose tier is not visible
+// to the viewer.
+const noTierName = "no tier"
+
+type BreakdownOptions struct {
+	Client   *api.GraphQLClient
+	IOs      Terminal
+	Prompter Prompter
+
+	Username  string
+	FieldsRaw string
+	Fields    []string
+	Quiet     bool
+}
+
+func NewCmdBreakdown(
+	client clientFunc,
+	ios Terminal,
+	prompter Prompter,
+	runF func(*BreakdownOptions) error,
+) *cobra.Command {
+	opts := &BreakdownOptions{
+		IOs:      ios,
+		Prompter: prompter,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "breakdown [<user>]",
+		Short: "Break down sponsors by tier",
+		Long: `Show the number of sponsors and the monthly amount they sum up to for each
+tier of a given user or organization, highest amount first.
+
+Sponsorship tiers are only visible to the sponsored account and its admins.
+Sponsors whose tier is not visible are grouped as "no tier".`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 1 {
+				return errors.New("too many arguments")
+			} else if len(args) == 1 {
+				opts.Username = args[0]
+			}
+
+			fields, err := parseFieldsOf(opts.FieldsRaw, breakdownFields)
+			if err != nil {
+				return err
+			}
+			opts.Fields = fields
+
+			opts.Quiet = isQuiet(cmd)
+
+			if runF != nil {
+				return runF(opts)
+			}
+
+			c, err := client()
+			if err != nil {
+				return err
+			}
+			opts.Client = c
+
+			return breakdownRun(cmd.Context(), opts)
+		},
+	}
+
+	// We can't use StringSliceVar method since it supports multiple assignments
+	// like: --json a,b --json c
+	cmd.Flags().StringVar(&opts.FieldsRaw, "json", "", "JSON fields")
+
+	return cmd
+}
+
+func breakdownRun(ctx context.Context, opts *BreakdownOptions) error {
+	username, err := resolveUsername(opts.Client, opts.IOs, opts.Prompter, opts.Username)
+	if err != nil {
+		return err
+	}
+
+	sponsors, _, err := listSponsors(ctx, opts.Client, username, 0, sponsorOrder("login", "asc"), 0, defaultMaxRetries, opts.IOs.ErrOut())
+	if err != nil {
+		return err
+	}
+	groups := groupSponsorsByTier(sponsors)
+
+	if opts.Fields != nil {
+		data := make([]any, 0, len(groups))
+		for _, g := range groups {
+			m := make(map[string]any, len(opts.Fields))
+			for _, f := range opts.Fields {
+				switch f {
+				case "tier":
+					m["tier"] = g.Tier
+				case "count":
+					m["count"] = g.Count
+				case "totalMonthly":
+					m["totalMonthly"] = g.TotalMonthly
+				}
+			}
+			data = append(data, m)
+		}
+
+		return printJSON(opts.IOs, data, opts.IOs.IsTerminalOutput())
+	}
+
+	if len(groups) == 0 {
+		if opts.IOs.IsTerminalOutput() && !opts.Quiet {
+			fmt.Fprintln(opts.IOs.ErrOut(), "no sponsor found")
+		}
+		return nil
+	}
+
+	for _, g := range groups {
+		if g.Count == 1 {
+			fmt.Fprintf(opts.IOs.Out(), "%s: 1 sponsor (%s/mo)\n", g.Tier, formatDollars(g.TotalMonthly))
+		} else {
+			fmt.Fprintf(opts.IOs.Out(), "%s: %d sponsors (%s/mo)\n", g.Tier, g.Count, formatDollars(g.TotalMonthly))
+		}
+	}
+	return nil
+}
+
+// tierGroup holds the sponsors of a tier.
+type tierGroup struct {
+	Tier  string
+	Count int
+	// TotalMonthly is the sum of the monthly amounts of the sponsors, in US
+	// dollars. It is zero for one-time tiers.
+	TotalMonthly int
+}
+
+// groupSponsorsByTier groups the sponsors by tier name, sorted by total
+// monthly amount descending, then by tier name.
+func groupSponsorsByTier(sponsors []sponsor) []tierGroup {
+	byTier := make(map[string]*tierGroup)
+	for _, s := range sponsors {
+		name := s.Tier
+		if name == "" {
+			name = noTierName
+		}
+		g, ok := byTier[name]
+		if !ok {
+			g = &tierGroup{Tier: name}
+			byTier[name] = g
+		}
+		g.Count++
+		g.TotalMonthly += s.AmountInDollars
+	}
+
+	groups := make([]tierGroup, 0, len(byTier))
+	for _, g := range byTier {
+		groups = append(groups, *g)
+	}
+	slices.SortFunc(groups, func(a, b tierGroup) int {
+		if c := cmp.Compare(b.TotalMonthly, a.TotalMonthly); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Tier, b.Tier)
+	})
+	return groups
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/prompter"
+	"github.com/google/shlex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCmdBreakdown(t *testing.T) {
+	tests := []struct {
+		name    string
+		cli     string
+		wants   BreakdownOptions
+		wantErr string
+	}{
+		{
+			name: "no arg",
+			cli:  "",
+			wants: BreakdownOptions{
+				Username: "",
+			},
+		}, {
+			name: "normal",
+			cli:  "johndoe",
+			wants: BreakdownOptions{
+				Username: "johndoe",
+			},
+		}, {
+			name: "normal json",
+			cli:  "--json tier,count,totalMonthly johndoe",
+			wants: BreakdownOptions{
+				Username: "johndoe",
+				Fields:   []string{"tier", "count", "totalMonthly"},
+			},
+		}, {
+			name: "json all",
+			cli:  "--json all johndoe",
+			wants: BreakdownOptions{
+				Username: "johndoe",
+				Fields:   []string{"tier", "count", "totalMonthly"},
+			},
+		}, {
+			name: "json trimmed and deduplicated",
+			cli:  "--json 'tier, count,tier' johndoe",
+			wants: BreakdownOptions{
+				Username: "johndoe",
+				Fields:   []string{"tier", "count"},
+			},
+		}, {
+			name:    "failure too many arguments",
+			cli:     "johndoe janedoe",
+			wantErr: "too many arguments",
+		}, {
+			name:    "failure json",
+			cli:     "--json login johndoe",
+			wantErr: "unknown JSON field: \"login\" (available fields: tier, count, totalMonthly)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			argv, err := shlex.Split(tt.cli)
+			assert.NoError(t, err)
+
+			var breakdownOpts *BreakdownOptions
+			cmd := NewCmdBreakdown(
+				nil, nil, nil,
+				func(opts *BreakdownOptions) error {
+					breakdownOpts = opts
+					return nil
+				},
+			)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			_, err = cmd.ExecuteC()
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			require.Equal(t, tt.wants.Username, breakdownOpts.Username)
+			require.Equal(t, tt.wants.Fields, breakdownOpts.Fields)
+		})
+	}
+}
+
+func Test_breakdownRun(t *testing.T) {
+	defaultHTTPStubs := func(t *testing.T, mt *mockTransport) {
+		node := func(login, tier string, dollars int) string {
+			return userEdge(login, fmt.Sprintf(`"sponsorshipsAsSponsor":{"nodes":[{"tier":{"name":%q,"monthlyPriceInDollars":%d,"monthlyPriceInCents":%d}}]}`, tier, dollars, dollars*100))
+		}
+		mt.respBody = sponsorsPage(6, "", false,
+			node("foo", "$5 a month", 5),
+			node("bar", "$5 a month", 5),
+			node("baz", "$5 a month", 5),
+			node("qux", "$10 a month", 10),
+			node("quux", "$1,500 a month", 1500),
+			userEdge("corge", `"sponsorshipsAsSponsor":{"nodes":[]}`),
+		)
+	}
+
+	tests := []struct {
+		name          string
+		tty           bool
+		opts          *BreakdownOptions
+		httpStubs     func(*testing.T, *mockTransport)
+		prompterStubs func(*testing.T, *prompter.PrompterMock)
+		wantStdout    []string
+		wantStderr    string
+		wantErr       string
+	}{
+		{
+			name: "normal tty",
+			tty:  true,
+			opts: &BreakdownOptions{
+				Username: "johndoe",
+			},
+			httpStubs: defaultHTTPStubs,
+			wantStdout: []string{
+				"$1,500 a month: 1 sponsor ($1,500/mo)",
+				"$5 a month: 3 sponsors ($15/mo)",
+				"$10 a month: 1 sponsor ($10/mo)",
+				"no tier: 1 sponsor ($0/mo)",
+			},
+		}, {
+			name:      "normal tty, no-username",
+			tty:       true,
+			opts:      &BreakdownOptions{},
+			httpStubs: defaultHTTPStubs,
+			prompterStubs: func(t *testing.T, pm *prompter.PrompterMock) {
+				pm.RegisterInput("Which user do you want to target?", func(_, def string) (string, error) {
+					assert.Empty(t, def)
+					return "johndoe", nil
+				})
+			},
+			wantStdout: []string{
+				"$1,500 a month: 1 sponsor ($1,500/mo)",
+				"$5 a month: 3 sponsors ($15/mo)",
+				"$10 a month: 1 sponsor ($10/mo)",
+				"no tier: 1 sponsor ($0/mo)",
+			},
+		}, {
+			name: "normal json",
+			tty:  false,
+			opts: &BreakdownOptions{
+				Username: "johndoe",
+				Fields:   breakdownFields,
+			},
+			httpStubs:  defaultHTTPStubs,
+			wantStdout: []string{`[{"count":1,"tier":"$1,500 a month","totalMonthly":1500},{"count":3,"tier":"$5 a month","totalMonthly":15},{"count":1,"tier":"$10 a month","totalMonthly":10},{"count":1,"tier":"no tier","totalMonthly":0}]`},
+		}, {
+			name: "normal tty, no sponsors",
+			tty:  true,
+			opts: &BreakdownOptions{
+				Username: "johndoe",
+			},
+			httpStubs: func(_ *testing.T, mt *mockTransport) {
+				mt.respBody = `{"data":{"repositoryOwner":{"sponsors":{"edges":[],"totalCount":0}}}}`
+			},
+			wantStderr: "no sponsor found\n",
+		}, {
+			name: "normal no-tty, no sponsors",
+			tty:  false,
+			opts: &BreakdownOptions{
+				Username: "johndoe",
+			},
+			httpStubs: func(_ *testing.T, mt *mockTransport) {
+				mt.respBody = `{"data":{"repositoryOwner":{"sponsors":{"edges":[],"totalCount":0}}}}`
+			},
+		}, {
+			name: "failure unknown account",
+			tty:  true,
+			opts: &BreakdownOptions{
+				Username: "johndoe",
+			},
+			httpStubs: func(_ *testing.T, mt *mockTransport) {
+				mt.respBody = `{"data":{"repositoryOwner":null}}`
+			},
+			wantErr: "no such user: \"johndoe\"",
+		}, {
+			name: "failure tty, prompt error",
+			tty:  true,
+			opts: &BreakdownOptions{},
+			prompterStubs: func(t *testing.T, pm *prompter.PrompterMock) {
+				pm.RegisterInput("Which user do you want to target?", func(_, def string) (string, error) {
+					return "", errors.New("prompt error")
+				})
+			},
+			wantErr: "prompt error",
+		}, {
+			name:    "failure no-tty, no-username",
+			tty:     false,
+			opts:    &BreakdownOptions{},
+			wantErr: "username not provided",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTransport := &mockTransport{}
+			client, err := api.NewGraphQLClient(api.ClientOptions{
+				Host:      "foo",
+				AuthToken: "bar",
+				Transport: mockTransport,
+			})
+			require.NoError(t, err)
+
+			pm := &prompter.PrompterMock{}
+			if tt.prompterStubs != nil {
+				tt.prompterStubs(t, pm)
+			}
+			tt.opts.Prompter = pm
+
+			ios := &mockTerminal{
+				width:  999,
+				height: 999,
+			}
+			ios.isTTY = tt.tty
+
+			tt.opts.IOs = ios
+			tt.opts.Client = client
+
+			if tt.httpStubs != nil {
+				tt.httpStubs(t, mockTransport)
+			}
+
+			err = breakdownRun(context.Background(), tt.opts)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			expectedStdout := ""
+			if len(tt.wantStdout) > 0 {
+				expectedStdout = fmt.Sprintf("%s\n", strings.Join(tt.wantStdout, "\n"))
+			}
+			assert.Equal(t, expectedStdout, ios.stdout.String())
+			assert.Equal(t, tt.wantStderr, ios.stderr.String())
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/config"
+)
+
+// cacheDirEnv is the environment variable overriding the directory of cached
+// API responses.
+const cacheDirEnv = "GH_SPONSORS_CACHE_DIR"
+
+// cacheDir returns the directory of cached API responses.
+func cacheDir() string {
+	if dir := os.Getenv(cacheDirEnv); dir != "" {
+		return dir
+	}
+	return filepath.Join(config.CacheDir(), "gh-sponsors")
+}
+
+// cacheOptions control how cacheTransport handles a request.
+type cacheOptions struct {
+	// TTL is how long responses are cached. Nothing is cached if it is zero.
+	TTL time.Duration
+	// Refresh skips cached responses, fetching and caching fresh ones.
+	Refresh bool
+}
+
+type cacheOptionsKey struct{}
+
+// withCache returns a context making cacheTransport cache responses as set in
+// the given options.
+func withCache(ctx context.Context, opts cacheOptions) context.Context {
+	return context.WithValue(ctx, cacheOptionsKey{}, opts)
+}
+
+// cacheTransport caches successful responses on disk, keyed by the request
+// method, URL, authorization and body, for as long as set in the request
+// context with withCache. Requests are not cached by default.
+type cacheTransport struct {
+	base http.RoundTripper
+	dir  string
+}
+
+func (t *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	opts, _ := req.Context().Value(cacheOptionsKey{}).(cacheOptions)
+	if opts.TTL <= 0 {
+		return t.base.RoundTrip(req)
+	}
+
+	var reqBody []byte
+	if req.Body != nil {
+		b, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		reqBody = b
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(reqBody)), nil
+		}
+	}
+	path := filepath.Join(t.dir, cacheKey(req, reqBody))
+
+	if !opts.Refresh {
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) < opts.TTL {
+			if body, err := os.ReadFile(path); err == nil {
+				return &http.Response{
+					Status:        "200 OK",
+					StatusCode:    http.StatusOK,
+					Header:        http.Header{"Content-Type": []string{"application/json"}},
+					Body:          io.NopCloser(bytes.NewReader(body)),
+					ContentLength: int64(len(body)),
+					Request:       req,
+				}, nil
+			}
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	// Don't cache GraphQL errors, which come with a 200 status code too.
+	var result struct {
+		Errors json.RawMessage `json:"errors"`
+	}
+	if json.Unmarshal(body, &result) != nil || len(result.Errors) > 0 {
+		return resp, nil
+	}
+
+	// Failing to cache a response is not worth failing the request.
+	if err := os.MkdirAll(t.dir, 0o755); err == nil {
+		_ = os.WriteFile(path, body, 0o600)
+	}
+	return resp, nil
+}
+
+// cacheKey returns the cache file name of the given request with the given
+// body.
+func cacheKey(req *http.Request, body []byte) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s:%s:%s:", req.Method, req.URL, req.Header.Get("Authorization"))
+	h.Write(body)
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_listSponsors_cache(t *testing.T) {
+	okBody := `{"data":{"repositoryOwner":{"sponsors":{"edges":[{"node":{"__typename":"User","login":"foo","name":""}}],"totalCount":1}}}}`
+
+	tests := []struct {
+		name         string
+		first        cacheOptions
+		second       cacheOptions
+		secondLogin  string
+		age          time.Duration
+		respBodies   []string
+		wantRequests int
+	}{
+		{
+			name:         "no cache",
+			wantRequests: 2,
+		}, {
+			name:         "cached",
+			first:        cacheOptions{TTL: time.Hour},
+			second:       cacheOptions{TTL: time.Hour},
+			wantRequests: 1,
+		}, {
+			name:         "cache expired",
+			first:        cacheOptions{TTL: time.Hour},
+			second:       cacheOptions{TTL: time.Hour},
+			age:          2 * time.Hour,
+			wantRequests: 2,
+		}, {
+			name:         "refresh",
+			first:        cacheOptions{TTL: time.Hour},
+			second:       cacheOptions{TTL: time.Hour, Refresh: true},
+			wantRequests: 2,
+		}, {
+			name:         "cached without ttl is ignored",
+			first:        cacheOptions{TTL: time.Hour},
+			wantRequests: 2,
+		}, {
+			name:         "other username",
+			first:        cacheOptions{TTL: time.Hour},
+			second:       cacheOptions{TTL: time.Hour},
+			secondLogin:  "janedoe",
+			wantRequests: 2,
+		}, {
+			name:         "graphql errors are not cached",
+			first:        cacheOptions{TTL: time.Hour},
+			second:       cacheOptions{TTL: time.Hour},
+			respBodies:   []string{`{"data":{}, "errors": [{"message": "some gql error"}]}`, okBody},
+			wantRequests: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			mockTransport := &mockTransport{
+				respBody:   okBody,
+				respBodies: tt.respBodies,
+			}
+			client, err := api.NewGraphQLClient(api.ClientOptions{
+				Host:      "github.com",
+				AuthToken: "bar",
+				Transport: &cacheTransport{base: mockTransport, dir: dir},
+			})
+			require.NoError(t, err)
+
+			order := sponsorOrder("login", "asc")
+			_, _, _ = listSponsors(withCache(context.Background(), tt.first), client, "johndoe", 30, order, 0, 0, io.Discard)
+
+			if tt.age > 0 {
+				entries, err := os.ReadDir(dir)
+				require.NoError(t, err)
+				past := time.Now().Add(-tt.age)
+				for _, e := range entries {
+					require.NoError(t, os.Chtimes(filepath.Join(dir, e.Name()), past, past))
+				}
+			}
+
+			login := tt.secondLogin
+			if login == "" {
+				login = "johndoe"
+			}
+			sponsors, total, err := listSponsors(withCache(context.Background(), tt.second), client, login, 30, order, 0, 0, io.Discard)
+			require.NoError(t, err)
+			assert.Equal(t, 1, total)
+			require.Len(t, sponsors, 1)
+			assert.Equal(t, "foo", sponsors[0].Login)
+
+			assert.Len(t, mockTransport.reqBodies, tt.wantRequests)
+		})
+	}
+}
+
+func Test_cacheDir(t *testing.T) {
+	t.Setenv(cacheDirEnv, "/tmp/sponsors-cache")
+	assert.Equal(t, "/tmp/sponsors-cache", cacheDir())
+
+	t.Setenv(cacheDirEnv, "")
+	t.Setenv("XDG_CACHE_HOME", "/tmp/xdg")
+	assert.Equal(t, filepath.Join("/tmp/xdg", "gh", "gh-sponsors"), cacheDir())
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/shurcooL/githubv4"
+	"github.com/spf13/cobra"
+)
+
+type CheckOptions struct {
+	Client *api.GraphQLClient
+	IOs    Terminal
+
+	Sponsor     string
+	Sponsorable string
+	JSON        bool
+}
+
+func NewCmdCheck(
+	client clientFunc,
+	ios Terminal,
+	runF func(*CheckOptions) error,
+) *cobra.Command {
+	opts := &CheckOptions{
+		IOs: ios,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "check <sponsor> <sponsorable>",
+		Short: "Check whether an account sponsors another",
+		Long: `Check whether a user or organization currently sponsors another.
+
+The command exits with status 0 if it does, and 1 if it does not.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 2 {
+				return errors.New("expected a sponsor and a sponsorable account")
+			}
+			opts.Sponsor = args[0]
+			opts.Sponsorable = args[1]
+
+			if runF != nil {
+				return runF(opts)
+			}
+
+			c, err := client()
+			if err != nil {
+				return err
+			}
+			opts.Client = c
+
+			return checkRun(opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Print the result as a JSON boolean")
+
+	return cmd
+}
+
+// checkRun reports whether the sponsor sponsors the sponsorable, returning
+// errSilent if it does not.
+func checkRun(opts *CheckOptions) error {
+	sponsored, err := isSponsoredBy(opts.Client, opts.Sponsorable, opts.Sponsor)
+	if err != nil {
+		return err
+	}
+
+	if opts.JSON {
+		fmt.Fprintln(opts.IOs.Out(), sponsored)
+	} else if opts.IOs.IsTerminalOutput() {
+		if sponsored {
+			fmt.Fprintf(opts.IOs.Out(), "%s is sponsoring %s\n", opts.Sponsor, opts.Sponsorable)
+		} else {
+			fmt.Fprintf(opts.IOs.Out(), "%s is not sponsoring %s\n", opts.Sponsor, opts.Sponsorable)
+		}
+	}
+
+	if !sponsored {
+		return errSilent
+	}
+	return nil
+}
+
+// isSponsoredBy reports whether the given sponsorable user or organization is
+// currently sponsored by the given account.
+func isSponsoredBy(client *api.GraphQLClient, sponsorable, sponsor string) (bool, error) {
+	var query struct {
+		RepositoryOwner *struct {
+			Sponsorable struct {
+				IsSponsoredBy githubv4.Boolean `graphql:"isSponsoredBy(accountLogin: $sponsor)"`
+			} `graphql:"... on Sponsorable"`
+		} `graphql:"repositoryOwner(login: $login)"`
+	}
+
+	variables := map[string]any{
+		"login":   githubv4.String(sponsorable),
+		"sponsor": githubv4.String(sponsor),
+	}
+
+	if err := client.Query("SponsorCheck", &query, variables); err != nil {
+		return false, fmt.Errorf("failed to check whether %q sponsors %q: %w", sponsor, sponsorable, translateQueryError(err))
+	}
+	if query.RepositoryOwner == nil {
+		return false, userNotFoundError(sponsorable)
+	}
+
+	return bool(query.RepositoryOwner.Sponsorable.IsSponsoredBy), nil
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/google/shlex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCmdCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		cli     string
+		wants   CheckOptions
+		wantErr string
+	}{
+		{
+			name: "normal",
+			cli:  "janedoe johndoe",
+			wants: CheckOptions{
+				Sponsor:     "janedoe",
+				Sponsorable: "johndoe",
+			},
+		}, {
+			name: "normal json",
+			cli:  "--json janedoe johndoe",
+			wants: CheckOptions{
+				Sponsor:     "janedoe",
+				Sponsorable: "johndoe",
+				JSON:        true,
+			},
+		}, {
+			name:    "failure no arg",
+			cli:     "",
+			wantErr: "expected a sponsor and a sponsorable account",
+		}, {
+			name:    "failure one arg",
+			cli:     "janedoe",
+			wantErr: "expected a sponsor and a sponsorable account",
+		}, {
+			name:    "failure too many arguments",
+			cli:     "janedoe johndoe foo",
+			wantErr: "expected a sponsor and a sponsorable account",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			argv, err := shlex.Split(tt.cli)
+			assert.NoError(t, err)
+
+			var checkOpts *CheckOptions
+			cmd := NewCmdCheck(
+				nil, nil,
+				func(opts *CheckOptions) error {
+					checkOpts = opts
+					return nil
+				},
+			)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			_, err = cmd.ExecuteC()
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			require.Equal(t, tt.wants.Sponsor, checkOpts.Sponsor)
+			require.Equal(t, tt.wants.Sponsorable, checkOpts.Sponsorable)
+			require.Equal(t, tt.wants.JSON, checkOpts.JSON)
+		})
+	}
+}
+
+func Test_checkRun(t *testing.T) {
+	sponsoredHTTPStubs := func(_ *testing.T, mt *mockTransport) {
+		mt.respBody = `{"data":{"repositoryOwner":{"isSponsoredBy":true}}}`
+	}
+	notSponsoredHTTPStubs := func(_ *testing.T, mt *mockTransport) {
+		mt.respBody = `{"data":{"repositoryOwner":{"isSponsoredBy":false}}}`
+	}
+
+	tests := []struct {
+		name       string
+		tty        bool
+		json       bool
+		httpStubs  func(*testing.T, *mockTransport)
+		wantStdout string
+		wantErr    error
+		wantErrMsg string
+	}{
+		{
+			name:       "sponsored tty",
+			tty:        true,
+			httpStubs:  sponsoredHTTPStubs,
+			wantStdout: "janedoe is sponsoring johndoe\n",
+		}, {
+			name:       "not sponsored tty",
+			tty:        true,
+			httpStubs:  notSponsoredHTTPStubs,
+			wantStdout: "janedoe is not sponsoring johndoe\n",
+			wantErr:    errSilent,
+		}, {
+			name:      "sponsored no-tty",
+			httpStubs: sponsoredHTTPStubs,
+		}, {
+			name:      "not sponsored no-tty",
+			httpStubs: notSponsoredHTTPStubs,
+			wantErr:   errSilent,
+		}, {
+			name:       "sponsored json",
+			tty:        true,
+			json:       true,
+			httpStubs:  sponsoredHTTPStubs,
+			wantStdout: "true\n",
+		}, {
+			name:       "not sponsored json",
+			json:       true,
+			httpStubs:  notSponsoredHTTPStubs,
+			wantStdout: "false\n",
+			wantErr:    errSilent,
+		}, {
+			name: "failure unknown account",
+			tty:  true,
+			httpStubs: func(_ *testing.T, mt *mockTransport) {
+				mt.respBody = `{"data":{"repositoryOwner":null}}`
+			},
+			wantErrMsg: "no such user: \"johndoe\"",
+		}, {
+			name: "api error",
+			tty:  true,
+			httpStubs: func(_ *testing.T, mt *mockTransport) {
+				mt.respBody = `{"data":{}, "errors": [{"message": "some gql error"}]}`
+			},
+			wantErrMsg: "failed to check whether \"janedoe\" sponsors \"johndoe\": GraphQL: some gql error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTransport := &mockTransport{}
+			client, err := api.NewGraphQLClient(api.ClientOptions{
+				Host:      "foo",
+				AuthToken: "bar",
+				Transport: mockTransport,
+			})
+			require.NoError(t, err)
+
+			ios := &mockTerminal{isTTY: tt.tty}
+			opts := &CheckOptions{
+				Client:      client,
+				IOs:         ios,
+				Sponsor:     "janedoe",
+				Sponsorable: "johndoe",
+				JSON:        tt.json,
+			}
+
+			if tt.httpStubs != nil {
+				tt.httpStubs(t, mockTransport)
+			}
+
+			err = checkRun(opts)
+			if tt.wantErrMsg != "" {
+				require.EqualError(t, err, tt.wantErrMsg)
+				return
+			}
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wantStdout, ios.stdout.String())
+			assert.Empty(t, ios.stderr.String())
+		})
+	}
+}
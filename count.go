@@ -0,0 +1,116 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/shurcooL/githubv4"
+	"github.com/spf13/cobra"
+)
+
+var countFields = []string{
+	"total",
+}
+
+type CountOptions struct {
+	Client   *api.GraphQLClient
+	IOs      Terminal
+	Prompter Prompter
+
+	Username  string
+	FieldsRaw string
+	Fields    []string
+}
+
+func NewCmdCount(
+	client clientFunc,
+	ios Terminal,
+	prompter Prompter,
+	runF func(*CountOptions) error,
+) *cobra.Command {
+	opts := &CountOptions{
+		IOs:      ios,
+		Prompter: prompter,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "count [<user>]",
+		Short: "Count sponsors",
+		Long:  `Print the total number of sponsors of a given user or organization.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 1 {
+				return errors.New("too many arguments")
+			} else if len(args) == 1 {
+				opts.Username = args[0]
+			}
+
+			fields, err := parseFieldsOf(opts.FieldsRaw, countFields)
+			if err != nil {
+				return err
+			}
+			opts.Fields = fields
+
+			if runF != nil {
+				return runF(opts)
+			}
+
+			c, err := client()
+			if err != nil {
+				return err
+			}
+			opts.Client = c
+
+			return countRun(opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.FieldsRaw, "json", "", "JSON fields")
+
+	return cmd
+}
+
+func countRun(opts *CountOptions) error {
+	username, err := resolveUsername(opts.Client, opts.IOs, opts.Prompter, opts.Username)
+	if err != nil {
+		return err
+	}
+
+	total, err := countSponsors(opts.Client, username)
+	if err != nil {
+		return err
+	}
+
+	if opts.Fields != nil {
+		return printJSON(opts.IOs, map[string]any{"total": total}, opts.IOs.IsTerminalOutput())
+	}
+
+	fmt.Fprintln(opts.IOs.Out(), total)
+	return nil
+}
+
+// countSponsors fetches the total number of sponsors of the given user or
+// organization, without fetching the sponsors themselves.
+func countSponsors(client *api.GraphQLClient, username string) (int, error) {
+	var query struct {
+		RepositoryOwner *struct {
+			Sponsorable struct {
+				Sponsors struct {
+					TotalCount githubv4.Int
+				} `graphql:"sponsors(first: 0)"`
+			} `graphql:"... on Sponsorable"`
+		} `graphql:"repositoryOwner(login: $login)"`
+	}
+
+	variables := map[string]any{
+		"login": githubv4.String(username),
+	}
+
+	if err := client.Query("SponsorCount", &query, variables); err != nil {
+		return 0, fmt.Errorf("failed to count sponsors for %q: %w", username, translateQueryError(err))
+	}
+	if query.RepositoryOwner == nil {
+		return 0, userNotFoundError(username)
+	}
+	return int(query.RepositoryOwner.Sponsorable.Sponsors.TotalCount), nil
+}
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/prompter"
+	"github.com/google/shlex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCmdCount(t *testing.T) {
+	tests := []struct {
+		name    string
+		cli     string
+		wants   CountOptions
+		wantErr string
+	}{
+		{
+			name: "no arg",
+			cli:  "",
+			wants: CountOptions{
+				Username: "",
+			},
+		}, {
+			name: "normal",
+			cli:  "johndoe",
+			wants: CountOptions{
+				Username: "johndoe",
+			},
+		}, {
+			name: "normal json",
+			cli:  "--json total johndoe",
+			wants: CountOptions{
+				Username: "johndoe",
+				Fields:   []string{"total"},
+			},
+		}, {
+			name: "json all",
+			cli:  "--json all johndoe",
+			wants: CountOptions{
+				Username: "johndoe",
+				Fields:   []string{"total"},
+			},
+		}, {
+			name: "json trimmed and deduplicated",
+			cli:  "--json ' total, total,' johndoe",
+			wants: CountOptions{
+				Username: "johndoe",
+				Fields:   []string{"total"},
+			},
+		}, {
+			name:    "failure too many arguments",
+			cli:     "johndoe janedoe",
+			wantErr: "too many arguments",
+		}, {
+			name:    "failure json",
+			cli:     "--json login johndoe",
+			wantErr: "unknown JSON field: \"login\" (available fields: total)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			argv, err := shlex.Split(tt.cli)
+			assert.NoError(t, err)
+
+			var countOpts *CountOptions
+			cmd := NewCmdCount(
+				nil, nil, nil,
+				func(opts *CountOptions) error {
+					countOpts = opts
+					return nil
+				},
+			)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			_, err = cmd.ExecuteC()
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			require.Equal(t, tt.wants.Username, countOpts.Username)
+			require.Equal(t, tt.wants.Fields, countOpts.Fields)
+		})
+	}
+}
+
+func Test_countRun(t *testing.T) {
+	defaultHTTPStubs := func(t *testing.T, mt *mockTransport) {
+		mt.respBody = `{"data":{"repositoryOwner":{"sponsors":{"totalCount":142}}}}`
+	}
+
+	tests := []struct {
+		name          string
+		tty           bool
+		opts          *CountOptions
+		httpStubs     func(*testing.T, *mockTransport)
+		prompterStubs func(*testing.T, *prompter.PrompterMock)
+		wantStdout    string
+		wantErr       string
+	}{
+		{
+			name: "normal tty",
+			tty:  true,
+			opts: &CountOptions{
+				Username: "johndoe",
+			},
+			httpStubs:  defaultHTTPStubs,
+			wantStdout: "142\n",
+		}, {
+			name:      "normal tty, no-username",
+			tty:       true,
+			opts:      &CountOptions{},
+			httpStubs: defaultHTTPStubs,
+			prompterStubs: func(t *testing.T, pm *prompter.PrompterMock) {
+				pm.RegisterInput("Which user do you want to target?", func(_, def string) (string, error) {
+					assert.Empty(t, def)
+					return "johndoe", nil
+				})
+			},
+			wantStdout: "142\n",
+		}, {
+			name: "normal no-tty",
+			tty:  false,
+			opts: &CountOptions{
+				Username: "johndoe",
+			},
+			httpStubs:  defaultHTTPStubs,
+			wantStdout: "142\n",
+		}, {
+			name: "normal json",
+			tty:  false,
+			opts: &CountOptions{
+				Username: "johndoe",
+				Fields:   []string{"total"},
+			},
+			httpStubs:  defaultHTTPStubs,
+			wantStdout: "{\"total\":142}\n",
+		}, {
+			name: "failure unknown account",
+			tty:  false,
+			opts: &CountOptions{
+				Username: "johndoe",
+			},
+			httpStubs: func(_ *testing.T, mt *mockTransport) {
+				mt.respBody = `{"data":{"repositoryOwner":null}}`
+			},
+			wantErr: "no such user: \"johndoe\"",
+		}, {
+			name: "failure tty, prompt error",
+			tty:  true,
+			opts: &CountOptions{},
+			prompterStubs: func(t *testing.T, pm *prompter.PrompterMock) {
+				pm.RegisterInput("Which user do you want to target?", func(_, def string) (string, error) {
+					return "", errors.New("prompt error")
+				})
+			},
+			wantErr: "prompt error",
+		}, {
+			name:    "failure no-tty, no-username",
+			tty:     false,
+			opts:    &CountOptions{},
+			wantErr: "username not provided",
+		}, {
+			name: "api error",
+			tty:  true,
+			opts: &CountOptions{
+				Username: "johndoe",
+			},
+			httpStubs: func(_ *testing.T, mt *mockTransport) {
+				mt.respBody = `{"data":{}, "errors": [{"message": "some gql error"}]}`
+			},
+			wantErr: "failed to count sponsors for \"johndoe\": GraphQL: some gql error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTransport := &mockTransport{}
+			client, err := api.NewGraphQLClient(api.ClientOptions{
+				Host:      "foo",
+				AuthToken: "bar",
+				Transport: mockTransport,
+			})
+			require.NoError(t, err)
+
+			pm := &prompter.PrompterMock{}
+			if tt.prompterStubs != nil {
+				tt.prompterStubs(t, pm)
+			}
+			tt.opts.Prompter = pm
+
+			ios := &mockTerminal{
+				width:  999,
+				height: 999,
+			}
+			ios.isTTY = tt.tty
+
+			tt.opts.IOs = ios
+			tt.opts.Client = client
+
+			if tt.httpStubs != nil {
+				tt.httpStubs(t, mockTransport)
+			}
+
+			err = countRun(tt.opts)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantStdout, ios.stdout.String())
+			assert.Empty(t, ios.stderr.String())
+		})
+	}
+}
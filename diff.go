@@ -0,0 +1,156 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/spf13/cobra"
+)
+
+type DiffOptions struct {
+	IOs Terminal
+
+	OldPath string
+	NewPath string
+	JSON    bool
+	Quiet   bool
+}
+
+func NewCmdDiff(
+	ios Terminal,
+	runF func(*DiffOptions) error,
+) *cobra.Command {
+	opts := &DiffOptions{
+		IOs: ios,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "diff <old> <new>",
+		Short: "Compare two sponsor snapshots",
+		Long: `Compare two snapshots written by the export command, and list the sponsors
+that were added or removed between them, by login.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 2 {
+				return errors.New("expected an old and a new snapshot file")
+			}
+			opts.OldPath = args[0]
+			opts.NewPath = args[1]
+
+			opts.Quiet = isQuiet(cmd)
+
+			if runF != nil {
+				return runF(opts)
+			}
+
+			return diffRun(opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Print the result as a JSON object")
+
+	return cmd
+}
+
+func diffRun(opts *DiffOptions) error {
+	oldLogins, err := readSnapshotLogins(opts.OldPath)
+	if err != nil {
+		return err
+	}
+	newLogins, err := readSnapshotLogins(opts.NewPath)
+	if err != nil {
+		return err
+	}
+
+	added, removed := diffLogins(oldLogins, newLogins)
+
+	if opts.JSON {
+		enc := json.NewEncoder(opts.IOs.Out())
+		if opts.IOs.IsTerminalOutput() {
+			enc.SetIndent("", "  ")
+		}
+		return enc.Encode(struct {
+			Added   []string `json:"added"`
+			Removed []string `json:"removed"`
+		}{added, removed})
+	}
+
+	if !opts.IOs.IsTerminalOutput() {
+		for _, login := range added {
+			fmt.Fprintf(opts.IOs.Out(), "added\t%s\n", login)
+		}
+		for _, login := range removed {
+			fmt.Fprintf(opts.IOs.Out(), "removed\t%s\n", login)
+		}
+		return nil
+	}
+
+	if len(added) == 0 && len(removed) == 0 {
+		if !opts.Quiet {
+			fmt.Fprintln(opts.IOs.ErrOut(), "no change")
+		}
+		return nil
+	}
+	if len(added) > 0 {
+		fmt.Fprintf(opts.IOs.Out(), "Added (%d):\n", len(added))
+		for _, login := range added {
+			fmt.Fprintf(opts.IOs.Out(), "  + %s\n", login)
+		}
+	}
+	if len(removed) > 0 {
+		fmt.Fprintf(opts.IOs.Out(), "Removed (%d):\n", len(removed))
+		for _, login := range removed {
+			fmt.Fprintf(opts.IOs.Out(), "  - %s\n", login)
+		}
+	}
+	return nil
+}
+
+// readSnapshotLogins reads a snapshot written by the export command and
+// returns the logins of its sponsors.
+func readSnapshotLogins(path string) ([]string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot %s: %w", path, err)
+	}
+
+	var snap snapshot
+	if err := json.Unmarshal(b, &snap); err != nil {
+		return nil, fmt.Errorf("malformed snapshot %s: %w", path, err)
+	}
+	if snap.Sponsors == nil {
+		return nil, fmt.Errorf("malformed snapshot %s: missing sponsors", path)
+	}
+
+	logins := make([]string, 0, len(snap.Sponsors))
+	for i, s := range snap.Sponsors {
+		m, _ := s.(map[string]any)
+		login, _ := m["login"].(string)
+		if login == "" {
+			return nil, fmt.Errorf("malformed snapshot %s: sponsor %d has no login", path, i)
+		}
+		logins = append(logins, login)
+	}
+	return logins, nil
+}
+
+// diffLogins returns the logins only in after and the ones only in before,
+// both sorted.
+func diffLogins(before, after []string) (added, removed []string) {
+	added, removed = []string{}, []string{}
+	for _, login := range after {
+		if !slices.Contains(before, login) && !slices.Contains(added, login) {
+			added = append(added, login)
+		}
+	}
+	for _, login := range before {
+		if !slices.Contains(after, login) && !slices.Contains(removed, login) {
+			removed = append(removed, login)
+		}
+	}
+	slices.Sort(added)
+	slices.Sort(removed)
+	return added, removed
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/shlex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCmdDiff(t *testing.T) {
+	tests := []struct {
+		name    string
+		cli     string
+		wants   DiffOptions
+		wantErr string
+	}{
+		{
+			name: "normal",
+			cli:  "old.json new.json",
+			wants: DiffOptions{
+				OldPath: "old.json",
+				NewPath: "new.json",
+			},
+		}, {
+			name: "normal json",
+			cli:  "--json old.json new.json",
+			wants: DiffOptions{
+				OldPath: "old.json",
+				NewPath: "new.json",
+				JSON:    true,
+			},
+		}, {
+			name:    "failure one arg",
+			cli:     "old.json",
+			wantErr: "expected an old and a new snapshot file",
+		}, {
+			name:    "failure too many arguments",
+			cli:     "old.json new.json foo.json",
+			wantErr: "expected an old and a new snapshot file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			argv, err := shlex.Split(tt.cli)
+			assert.NoError(t, err)
+
+			var diffOpts *DiffOptions
+			cmd := NewCmdDiff(
+				nil,
+				func(opts *DiffOptions) error {
+					diffOpts = opts
+					return nil
+				},
+			)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			_, err = cmd.ExecuteC()
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			require.Equal(t, tt.wants.OldPath, diffOpts.OldPath)
+			require.Equal(t, tt.wants.NewPath, diffOpts.NewPath)
+			require.Equal(t, tt.wants.JSON, diffOpts.JSON)
+		})
+	}
+}
+
+func Test_diffRun(t *testing.T) {
+	oldSnapshot := `{"username":"johndoe","exportedAt":"2024-02-01T10:00:00Z","totalCount":3,"sponsors":[
+		{"login":"foo"},{"login":"bar"},{"login":"baz"}
+	]}`
+	newSnapshot := `{"username":"johndoe","exportedAt":"2024-03-01T10:00:00Z","totalCount":3,"sponsors":[
+		{"login":"foo"},{"login":"qux"},{"login":"acme"}
+	]}`
+
+	tests := []struct {
+		name       string
+		tty        bool
+		json       bool
+		old        string
+		new        string
+		wantStdout string
+		wantStderr string
+		wantErr    string
+	}{
+		{
+			name:       "normal tty",
+			tty:        true,
+			old:        oldSnapshot,
+			new:        newSnapshot,
+			wantStdout: "Added (2):\n  + acme\n  + qux\nRemoved (2):\n  - bar\n  - baz\n",
+		}, {
+			name:       "normal no-tty",
+			old:        oldSnapshot,
+			new:        newSnapshot,
+			wantStdout: "added\tacme\nadded\tqux\nremoved\tbar\nremoved\tbaz\n",
+		}, {
+			name:       "normal json",
+			json:       true,
+			old:        oldSnapshot,
+			new:        newSnapshot,
+			wantStdout: `{"added":["acme","qux"],"removed":["bar","baz"]}` + "\n",
+		}, {
+			name:       "no change tty",
+			tty:        true,
+			old:        oldSnapshot,
+			new:        oldSnapshot,
+			wantStderr: "no change\n",
+		}, {
+			name:       "no change json",
+			json:       true,
+			old:        oldSnapshot,
+			new:        oldSnapshot,
+			wantStdout: `{"added":[],"removed":[]}` + "\n",
+		}, {
+			name:    "failure malformed json",
+			old:     `{"sponsors":[`,
+			new:     newSnapshot,
+			wantErr: "malformed snapshot old.json: unexpected end of JSON input",
+		}, {
+			name:    "failure missing sponsors",
+			old:     oldSnapshot,
+			new:     `{"username":"johndoe"}`,
+			wantErr: "malformed snapshot new.json: missing sponsors",
+		}, {
+			name:    "failure missing login",
+			old:     `{"sponsors":[{"login":"foo"},{"name":"Bar"}]}`,
+			new:     newSnapshot,
+			wantErr: "malformed snapshot old.json: sponsor 1 has no login",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			oldPath := filepath.Join(dir, "old.json")
+			newPath := filepath.Join(dir, "new.json")
+			require.NoError(t, os.WriteFile(oldPath, []byte(tt.old), 0o644))
+			require.NoError(t, os.WriteFile(newPath, []byte(tt.new), 0o644))
+			t.Chdir(dir)
+
+			ios := &mockTerminal{isTTY: tt.tty}
+			opts := &DiffOptions{
+				IOs:     ios,
+				OldPath: "old.json",
+				NewPath: "new.json",
+				JSON:    tt.json,
+			}
+
+			err := diffRun(opts)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantStdout, ios.stdout.String())
+			assert.Equal(t, tt.wantStderr, ios.stderr.String())
+		})
+	}
+}
+
+func Test_diffRun_missingFile(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	ios := &mockTerminal{}
+	err := diffRun(&DiffOptions{
+		IOs:     ios,
+		OldPath: "old.json",
+		NewPath: "new.json",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to read snapshot old.json")
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+// now returns the current time. It is replaced in tests.
+var now = time.Now
+
+// snapshot is the document written by the export command, holding all the
+// fields of all the sponsors of an account at a given time.
+type snapshot struct {
+	Username   string    `json:"username"`
+	ExportedAt time.Time `json:"exportedAt"`
+	TotalCount int       `json:"totalCount"`
+	Sponsors   []any     `json:"sponsors"`
+}
+
+type ExportOptions struct {
+	Client   *api.GraphQLClient
+	IOs      Terminal
+	Prompter Prompter
+
+	Username string
+	Output   string
+	Quiet    bool
+}
+
+func NewCmdExport(
+	client clientFunc,
+	ios Terminal,
+	prompter Prompter,
+	runF func(*ExportOptions) error,
+) *cobra.Command {
+	opts := &ExportOptions{
+		IOs:      ios,
+		Prompter: prompter,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "export [<user>]",
+		Short: "Export a snapshot of all sponsors",
+		Long: `Export a JSON snapshot of all the sponsors of a given user or organization,
+with all their fields, the total number of sponsors and the time of the
+export.
+
+Snapshots can be compared with the diff command.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 1 {
+				return errors.New("too many arguments")
+			} else if len(args) == 1 {
+				opts.Username = args[0]
+			}
+
+			opts.Quiet = isQuiet(cmd)
+
+			if runF != nil {
+				return runF(opts)
+			}
+
+			c, err := client()
+			if err != nil {
+				return err
+			}
+			opts.Client = c
+
+			return exportRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Output, "out", "o", "", "Write the snapshot to the given file instead of stdout")
+
+	return cmd
+}
+
+func exportRun(ctx context.Context, opts *ExportOptions) (err error) {
+	username, err := resolveUsername(opts.Client, opts.IOs, opts.Prompter, opts.Username)
+	if err != nil {
+		return err
+	}
+
+	sponsors, total, err := listSponsors(ctx, opts.Client, username, 0, sponsorOrder("login", "asc"), defaultAvatarSize, defaultMaxRetries, opts.IOs.ErrOut())
+	if err != nil {
+		return err
+	}
+
+	snap := snapshot{
+		Username:   username,
+		ExportedAt: now().UTC().Truncate(time.Second),
+		TotalCount: total,
+		Sponsors:   sponsorsData(sponsors, listFields),
+	}
+
+	var w io.Writer = opts.IOs.Out()
+	if opts.Output != "" {
+		f, err := os.Create(opts.Output)
+		if err != nil {
+			return fmt.Errorf("failed to create output file %s: %w", opts.Output, err)
+		}
+		defer func() {
+			if cerr := f.Close(); cerr != nil && err == nil {
+				err = fmt.Errorf("failed to write output file %s: %w", opts.Output, cerr)
+			}
+		}()
+		w = f
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(snap); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+
+	if opts.Output != "" && opts.IOs.IsTerminalOutput() && !opts.Quiet {
+		if len(sponsors) == 1 {
+			fmt.Fprintf(opts.IOs.ErrOut(), "wrote 1 sponsor to %s\n", opts.Output)
+		} else {
+			fmt.Fprintf(opts.IOs.ErrOut(), "wrote %d sponsors to %s\n", len(sponsors), opts.Output)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/prompter"
+	"github.com/google/shlex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCmdExport(t *testing.T) {
+	tests := []struct {
+		name    string
+		cli     string
+		wants   ExportOptions
+		wantErr string
+	}{
+		{
+			name: "no arg",
+			cli:  "",
+		}, {
+			name: "normal",
+			cli:  "johndoe",
+			wants: ExportOptions{
+				Username: "johndoe",
+			},
+		}, {
+			name: "normal out",
+			cli:  "--out snapshot.json johndoe",
+			wants: ExportOptions{
+				Username: "johndoe",
+				Output:   "snapshot.json",
+			},
+		}, {
+			name:    "failure too many arguments",
+			cli:     "johndoe janedoe",
+			wantErr: "too many arguments",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			argv, err := shlex.Split(tt.cli)
+			assert.NoError(t, err)
+
+			var exportOpts *ExportOptions
+			cmd := NewCmdExport(
+				nil, nil, nil,
+				func(opts *ExportOptions) error {
+					exportOpts = opts
+					return nil
+				},
+			)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			_, err = cmd.ExecuteC()
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			require.Equal(t, tt.wants.Username, exportOpts.Username)
+			require.Equal(t, tt.wants.Output, exportOpts.Output)
+		})
+	}
+}
+
+func Test_exportRun(t *testing.T) {
+	origNow := now
+	now = func() time.Time { return time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC) }
+	t.Cleanup(func() { now = origNow })
+
+	respBody := `{"data":{"repositoryOwner":{"sponsors":{"edges":[
+		{"node":{"__typename":"User","login":"foo","name":"Foo"}},
+		{"node":{"__typename":"Organization","login":"acme","name":"Acme"}}
+	],"pageInfo":{"hasNextPage":false},"totalCount":2}}}}`
+
+	tests := []struct {
+		name       string
+		tty        bool
+		output     string
+		respBody   string
+		wantStderr string
+		wantErr    string
+	}{
+		{
+			name:       "file tty",
+			tty:        true,
+			output:     "snapshot.json",
+			respBody:   respBody,
+			wantStderr: "wrote 2 sponsors to snapshot.json\n",
+		}, {
+			name:     "file no-tty",
+			output:   "snapshot.json",
+			respBody: respBody,
+		}, {
+			name:     "stdout",
+			respBody: respBody,
+		}, {
+			name:     "failure missing directory",
+			output:   "missing/snapshot.json",
+			respBody: respBody,
+			wantErr:  "failed to create output file missing/snapshot.json",
+		}, {
+			name:     "failure unknown account",
+			output:   "snapshot.json",
+			respBody: `{"data":{"repositoryOwner":null}}`,
+			wantErr:  "no such user: \"johndoe\"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+
+			client, err := api.NewGraphQLClient(api.ClientOptions{
+				Host:      "foo",
+				AuthToken: "bar",
+				Transport: &mockTransport{respBody: tt.respBody},
+			})
+			require.NoError(t, err)
+
+			ios := &mockTerminal{isTTY: tt.tty}
+			opts := &ExportOptions{
+				Client:   client,
+				IOs:      ios,
+				Prompter: &prompter.PrompterMock{},
+				Username: "johndoe",
+				Output:   tt.output,
+			}
+
+			err = exportRun(context.Background(), opts)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			out := ios.stdout.Bytes()
+			if tt.output != "" {
+				assert.Empty(t, ios.stdout.String())
+				out, err = os.ReadFile(tt.output)
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantStderr, ios.stderr.String())
+
+			var snap struct {
+				Username   string           `json:"username"`
+				ExportedAt string           `json:"exportedAt"`
+				TotalCount int              `json:"totalCount"`
+				Sponsors   []map[string]any `json:"sponsors"`
+			}
+			require.NoError(t, json.Unmarshal(out, &snap))
+			assert.Equal(t, "johndoe", snap.Username)
+			assert.Equal(t, "2024-03-01T10:00:00Z", snap.ExportedAt)
+			assert.Equal(t, 2, snap.TotalCount)
+			require.Len(t, snap.Sponsors, 2)
+			assert.Equal(t, "foo", snap.Sponsors[0]["login"])
+			assert.Equal(t, "Organization", snap.Sponsors[1]["type"])
+			for _, f := range listFields {
+				assert.Contains(t, snap.Sponsors[0], f)
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/shurcooL/githubv4"
+	"github.com/spf13/cobra"
+)
+
+var goalFields = []string{
+	"title",
+	"percentComplete",
+	"targetValue",
+}
+
+// goalKindMonthlySponsorshipAmount is the SponsorsGoalKind of goals targeting
+// a monthly amount in US dollars. Other goals target a number of sponsors.
+const goalKindMonthlySponsorshipAmount = "MONTHLY_SPONSORSHIP_AMOUNT"
+
+type GoalOptions struct {
+	Client   *api.GraphQLClient
+	IOs      Terminal
+	Prompter Prompter
+
+	Username  string
+	FieldsRaw string
+	Fields    []string
+	Quiet     bool
+}
+
+func NewCmdGoal(
+	client clientFunc,
+	ios Terminal,
+	prompter Prompter,
+	runF func(*GoalOptions) error,
+) *cobra.Command {
+	opts := &GoalOptions{
+		IOs:      ios,
+		Prompter: prompter,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "goal [<user>]",
+		Short: "Show the active sponsorship goal",
+		Long: `Show the progress of the active sponsorship goal of a given user or
+organization.
+
+The targetValue field holds either a number of sponsors or a monthly amount in
+US dollars, depending on the kind of the goal.`,
+		Aliases: []string{"goals"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 1 {
+				return errors.New("too many arguments")
+			} else if len(args) == 1 {
+				opts.Username = args[0]
+			}
+
+			fields, err := parseFieldsOf(opts.FieldsRaw, goalFields)
+			if err != nil {
+				return err
+			}
+			opts.Fields = fields
+
+			opts.Quiet = isQuiet(cmd)
+
+			if runF != nil {
+				return runF(opts)
+			}
+
+			c, err := client()
+			if err != nil {
+				return err
+			}
+			opts.Client = c
+
+			return goalRun(opts)
+		},
+	}
+
+	// We can't use StringSliceVar method since it supports multiple assignments
+	// like: --json a,b --json c
+	cmd.Flags().StringVar(&opts.FieldsRaw, "json", "", "JSON fields")
+
+	return cmd
+}
+
+func goalRun(opts *GoalOptions) error {
+	username, err := resolveUsername(opts.Client, opts.IOs, opts.Prompter, opts.Username)
+	if err != nil {
+		return err
+	}
+
+	goal, err := activeGoal(opts.Client, username)
+	if err != nil {
+		return err
+	}
+
+	if goal == nil {
+		if opts.IOs.IsTerminalOutput() && !opts.Quiet {
+			fmt.Fprintln(opts.IOs.ErrOut(), "no active goal")
+		}
+		return nil
+	}
+
+	if opts.Fields != nil {
+		m := make(map[string]any, len(opts.Fields))
+		for _, f := range opts.Fields {
+			switch f {
+			case "title":
+				m["title"] = goal.Title
+			case "percentComplete":
+				m["percentComplete"] = goal.PercentComplete
+			case "targetValue":
+				m["targetValue"] = goal.TargetValue
+			}
+		}
+
+		return printJSON(opts.IOs, m, opts.IOs.IsTerminalOutput())
+	}
+
+	fmt.Fprintf(opts.IOs.Out(), "%s: %d%% of %s\n", goal.Title, goal.PercentComplete, goal.target())
+	return nil
+}
+
+type goal struct {
+	Kind            string
+	Title           string
+	PercentComplete int
+	TargetValue     int
+}
+
+// target returns the human-readable target of the goal, e.g. "100 sponsors"
+// or "$500 per month".
+func (g goal) target() string {
+	if g.Kind == goalKindMonthlySponsorshipAmount {
+		return formatDollars(g.TargetValue) + " per month"
+	}
+	if g.TargetValue == 1 {
+		return "1 sponsor"
+	}
+	return fmt.Sprintf("%d sponsors", g.TargetValue)
+}
+
+// activeGoal fetches the active sponsorship goal of the given user or
+// organization. It returns nil if the account has no GitHub Sponsors profile
+// or no active goal.
+func activeGoal(client *api.GraphQLClient, username string) (*goal, error) {
+	var query struct {
+		RepositoryOwner *struct {
+			Sponsorable struct {
+				SponsorsListing *struct {
+					ActiveGoal *struct {
+						Kind            githubv4.String
+						Title           githubv4.String
+						PercentComplete githubv4.Int
+						TargetValue     githubv4.Int
+					}
+				}
+			} `graphql:"... on Sponsorable"`
+		} `graphql:"repositoryOwner(login: $login)"`
+	}
+
+	variables := map[string]any{
+		"login": githubv4.String(username),
+	}
+
+	if err := client.Query("SponsorGoal", &query, variables); err != nil {
+		return nil, fmt.Errorf("failed to get the sponsors goal for %q: %w", username, translateQueryError(err))
+	}
+	if query.RepositoryOwner == nil {
+		return nil, userNotFoundError(username)
+	}
+
+	listing := query.RepositoryOwner.Sponsorable.SponsorsListing
+	if listing == nil || listing.ActiveGoal == nil {
+		return nil, nil
+	}
+
+	return &goal{
+		Kind:            string(listing.ActiveGoal.Kind),
+		Title:           string(listing.ActiveGoal.Title),
+		PercentComplete: int(listing.ActiveGoal.PercentComplete),
+		TargetValue:     int(listing.ActiveGoal.TargetValue),
+	}, nil
+}
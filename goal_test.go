@@ -0,0 +1,294 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/prompter"
+	"github.com/google/shlex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCmdGoal(t *testing.T) {
+	tests := []struct {
+		name    string
+		cli     string
+		wants   GoalOptions
+		wantErr string
+	}{
+		{
+			name: "no arg",
+			cli:  "",
+			wants: GoalOptions{
+				Username: "",
+			},
+		}, {
+			name: "normal",
+			cli:  "johndoe",
+			wants: GoalOptions{
+				Username: "johndoe",
+			},
+		}, {
+			name: "normal json",
+			cli:  "--json title,percentComplete,targetValue johndoe",
+			wants: GoalOptions{
+				Username: "johndoe",
+				Fields:   []string{"title", "percentComplete", "targetValue"},
+			},
+		}, {
+			name: "json all",
+			cli:  "--json all johndoe",
+			wants: GoalOptions{
+				Username: "johndoe",
+				Fields:   []string{"title", "percentComplete", "targetValue"},
+			},
+		}, {
+			name: "json trimmed and deduplicated",
+			cli:  "--json 'title, title,targetValue' johndoe",
+			wants: GoalOptions{
+				Username: "johndoe",
+				Fields:   []string{"title", "targetValue"},
+			},
+		}, {
+			name:    "failure too many arguments",
+			cli:     "johndoe janedoe",
+			wantErr: "too many arguments",
+		}, {
+			name:    "failure json",
+			cli:     "--json login johndoe",
+			wantErr: "unknown JSON field: \"login\" (available fields: title, percentComplete, targetValue)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			argv, err := shlex.Split(tt.cli)
+			assert.NoError(t, err)
+
+			var goalOpts *GoalOptions
+			cmd := NewCmdGoal(
+				nil, nil, nil,
+				func(opts *GoalOptions) error {
+					goalOpts = opts
+					return nil
+				},
+			)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			_, err = cmd.ExecuteC()
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			require.Equal(t, tt.wants.Username, goalOpts.Username)
+			require.Equal(t, tt.wants.Fields, goalOpts.Fields)
+		})
+	}
+}
+
+func Test_goalRun(t *testing.T) {
+	defaultHTTPStubs := func(t *testing.T, mt *mockTransport) {
+		mt.respBody = `
+				{
+					"data": {
+						"repositoryOwner": {
+							"sponsorsListing": {
+								"activeGoal": {
+									"kind": "TOTAL_SPONSORS_COUNT",
+									"title": "Get to 100 sponsors",
+									"percentComplete": 42,
+									"targetValue": 100
+								}
+							}
+						}
+					}
+				}`
+	}
+
+	tests := []struct {
+		name          string
+		tty           bool
+		opts          *GoalOptions
+		httpStubs     func(*testing.T, *mockTransport)
+		prompterStubs func(*testing.T, *prompter.PrompterMock)
+		wantStdout    []string
+		wantStderr    string
+		wantErr       string
+	}{
+		{
+			name: "normal tty",
+			tty:  true,
+			opts: &GoalOptions{
+				Username: "johndoe",
+			},
+			httpStubs: defaultHTTPStubs,
+			wantStdout: []string{
+				"Get to 100 sponsors: 42% of 100 sponsors",
+			},
+		}, {
+			name:      "normal tty, no-username",
+			tty:       true,
+			opts:      &GoalOptions{},
+			httpStubs: defaultHTTPStubs,
+			prompterStubs: func(t *testing.T, pm *prompter.PrompterMock) {
+				pm.RegisterInput("Which user do you want to target?", func(_, def string) (string, error) {
+					assert.Empty(t, def)
+					return "johndoe", nil
+				})
+			},
+			wantStdout: []string{
+				"Get to 100 sponsors: 42% of 100 sponsors",
+			},
+		}, {
+			name: "normal no-tty",
+			tty:  false,
+			opts: &GoalOptions{
+				Username: "johndoe",
+			},
+			httpStubs: defaultHTTPStubs,
+			wantStdout: []string{
+				"Get to 100 sponsors: 42% of 100 sponsors",
+			},
+		}, {
+			name: "normal json",
+			tty:  false,
+			opts: &GoalOptions{
+				Username: "johndoe",
+				Fields:   goalFields,
+			},
+			httpStubs:  defaultHTTPStubs,
+			wantStdout: []string{`{"percentComplete":42,"targetValue":100,"title":"Get to 100 sponsors"}`},
+		}, {
+			name: "normal tty, monthly amount goal",
+			tty:  true,
+			opts: &GoalOptions{
+				Username: "johndoe",
+			},
+			httpStubs: func(_ *testing.T, mt *mockTransport) {
+				mt.respBody = `{"data":{"repositoryOwner":{"sponsorsListing":{"activeGoal":{"kind":"MONTHLY_SPONSORSHIP_AMOUNT","title":"Fund full-time work","percentComplete":75,"targetValue":2000}}}}}`
+			},
+			wantStdout: []string{
+				"Fund full-time work: 75% of $2,000 per month",
+			},
+		}, {
+			name: "normal tty, no active goal",
+			tty:  true,
+			opts: &GoalOptions{
+				Username: "johndoe",
+			},
+			httpStubs: func(_ *testing.T, mt *mockTransport) {
+				mt.respBody = `{"data":{"repositoryOwner":{"sponsorsListing":{"activeGoal":null}}}}`
+			},
+			wantStderr: "no active goal\n",
+		}, {
+			name: "normal no-tty, no active goal",
+			tty:  false,
+			opts: &GoalOptions{
+				Username: "johndoe",
+			},
+			httpStubs: func(_ *testing.T, mt *mockTransport) {
+				mt.respBody = `{"data":{"repositoryOwner":{"sponsorsListing":{"activeGoal":null}}}}`
+			},
+		}, {
+			name: "normal tty, no sponsors listing",
+			tty:  true,
+			opts: &GoalOptions{
+				Username: "johndoe",
+			},
+			httpStubs: func(_ *testing.T, mt *mockTransport) {
+				mt.respBody = `{"data":{"repositoryOwner":{"sponsorsListing":null}}}`
+			},
+			wantStderr: "no active goal\n",
+		}, {
+			name: "failure unknown account",
+			tty:  true,
+			opts: &GoalOptions{
+				Username: "johndoe",
+			},
+			httpStubs: func(_ *testing.T, mt *mockTransport) {
+				mt.respBody = `{"data":{"repositoryOwner":null}}`
+			},
+			wantErr: "no such user: \"johndoe\"",
+		}, {
+			name: "failure tty, prompt error",
+			tty:  true,
+			opts: &GoalOptions{},
+			prompterStubs: func(t *testing.T, pm *prompter.PrompterMock) {
+				pm.RegisterInput("Which user do you want to target?", func(_, def string) (string, error) {
+					return "", errors.New("prompt error")
+				})
+			},
+			wantErr: "prompt error",
+		}, {
+			name:    "failure no-tty, no-username",
+			tty:     false,
+			opts:    &GoalOptions{},
+			wantErr: "username not provided",
+		}, {
+			name: "api error",
+			tty:  true,
+			opts: &GoalOptions{
+				Username: "johndoe",
+			},
+			httpStubs: func(_ *testing.T, mt *mockTransport) {
+				mt.respBody = `{"data":{}, "errors": [{"message": "some gql error"}]}`
+			},
+			wantErr: "failed to get the sponsors goal for \"johndoe\": GraphQL: some gql error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTransport := &mockTransport{}
+			client, err := api.NewGraphQLClient(api.ClientOptions{
+				Host:      "foo",
+				AuthToken: "bar",
+				Transport: mockTransport,
+			})
+			require.NoError(t, err)
+
+			pm := &prompter.PrompterMock{}
+			if tt.prompterStubs != nil {
+				tt.prompterStubs(t, pm)
+			}
+			tt.opts.Prompter = pm
+
+			ios := &mockTerminal{
+				width:  999,
+				height: 999,
+			}
+			ios.isTTY = tt.tty
+
+			tt.opts.IOs = ios
+			tt.opts.Client = client
+
+			if tt.httpStubs != nil {
+				tt.httpStubs(t, mockTransport)
+			}
+
+			err = goalRun(tt.opts)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			expectedStdout := ""
+			if len(tt.wantStdout) > 0 {
+				expectedStdout = fmt.Sprintf("%s\n", strings.Join(tt.wantStdout, "\n"))
+			}
+			assert.Equal(t, expectedStdout, ios.stdout.String())
+			assert.Equal(t, tt.wantStderr, ios.stderr.String())
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+// historySize is the maximum number of usernames kept in the history.
+const historySize = 50
+
+// historyPath returns the path of the file holding the usernames recently
+// listed, most recent first.
+func historyPath() string {
+	return filepath.Join(config.ConfigDir(), "gh-sponsors", "history")
+}
+
+// readHistory reads the usernames in the history file at the given path. A
+// missing file is an empty history.
+func readHistory(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var usernames []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			usernames = append(usernames, line)
+		}
+	}
+	return usernames, scanner.Err()
+}
+
+// addToHistory moves the given username to the top of the history file at the
+// given path, keeping at most historySize usernames.
+func addToHistory(path, username string) error {
+	history, err := readHistory(path)
+	if err != nil {
+		return err
+	}
+
+	usernames := []string{username}
+	for _, u := range history {
+		if len(usernames) == historySize {
+			break
+		}
+		if !slices.Contains(usernames, u) {
+			usernames = append(usernames, u)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(strings.Join(usernames, "\n")+"\n"), 0o600)
+}
+
+// completionTimeout bounds the query of the viewer's login when completing
+// usernames, so that a slow network does not block the shell. It is replaced
+// in tests.
+var completionTimeout = time.Second
+
+// completeUsernames returns a completion function for the username arguments,
+// suggesting the usernames in the history followed by the viewer's login,
+// except those already given. Failing to read either, or to query the login
+// within completionTimeout, just leaves them out.
+func completeUsernames(client clientFunc) cobra.CompletionFunc {
+	return func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		usernames, _ := readHistory(historyPath())
+		if client != nil {
+			if c, err := client(); err == nil {
+				ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+				defer cancel()
+				if login, err := viewerLogin(ctx, c); err == nil && !slices.Contains(usernames, login) {
+					usernames = append(usernames, login)
+				}
+			}
+		}
+
+		var completions []string
+		for _, u := range usernames {
+			if strings.HasPrefix(u, toComplete) && !slices.Contains(args, u) {
+				completions = append(completions, u)
+			}
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+}
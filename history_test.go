@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_readHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history")
+
+	usernames, err := readHistory(path)
+	require.NoError(t, err)
+	assert.Empty(t, usernames)
+
+	require.NoError(t, os.WriteFile(path, []byte("foo\n\n bar \n"), 0o600))
+	usernames, err = readHistory(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"foo", "bar"}, usernames)
+}
+
+func Test_addToHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gh-sponsors", "history")
+
+	require.NoError(t, addToHistory(path, "foo"))
+	require.NoError(t, addToHistory(path, "bar"))
+	require.NoError(t, addToHistory(path, "foo"))
+
+	usernames, err := readHistory(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"foo", "bar"}, usernames)
+
+	for i := range historySize + 10 {
+		require.NoError(t, addToHistory(path, fmt.Sprintf("user%d", i)))
+	}
+
+	usernames, err = readHistory(path)
+	require.NoError(t, err)
+	require.Len(t, usernames, historySize)
+	assert.Equal(t, fmt.Sprintf("user%d", historySize+9), usernames[0])
+	assert.Equal(t, "user10", usernames[historySize-1])
+}
+
+func Test_completeUsernames(t *testing.T) {
+	tests := []struct {
+		name       string
+		history    []string
+		respBody   string
+		args       []string
+		toComplete string
+		want       []string
+	}{
+		{
+			name:     "no history",
+			respBody: `{"data":{"viewer":{"login":"me"}}}`,
+			want:     []string{"me"},
+		}, {
+			name:     "history and viewer",
+			history:  []string{"foo", "bar"},
+			respBody: `{"data":{"viewer":{"login":"me"}}}`,
+			want:     []string{"foo", "bar", "me"},
+		}, {
+			name:     "viewer in history",
+			history:  []string{"me", "foo"},
+			respBody: `{"data":{"viewer":{"login":"me"}}}`,
+			want:     []string{"me", "foo"},
+		}, {
+			name:       "prefix",
+			history:    []string{"foo", "bar", "baz"},
+			respBody:   `{"data":{"viewer":{"login":"me"}}}`,
+			toComplete: "ba",
+			want:       []string{"bar", "baz"},
+		}, {
+			name:     "already given",
+			history:  []string{"foo", "bar"},
+			respBody: `{"data":{"viewer":{"login":"me"}}}`,
+			args:     []string{"foo"},
+			want:     []string{"bar", "me"},
+		}, {
+			name:     "viewer error",
+			history:  []string{"foo"},
+			respBody: `{"data":{}, "errors": [{"message": "some gql error"}]}`,
+			want:     []string{"foo"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("GH_CONFIG_DIR", t.TempDir())
+			for i := len(tt.history) - 1; i >= 0; i-- {
+				require.NoError(t, addToHistory(historyPath(), tt.history[i]))
+			}
+
+			client, err := api.NewGraphQLClient(api.ClientOptions{
+				Host:      "foo",
+				AuthToken: "bar",
+				Transport: &mockTransport{respBody: tt.respBody},
+			})
+			require.NoError(t, err)
+
+			got, directive := completeUsernames(func() (*api.GraphQLClient, error) { return client, nil })(nil, tt.args, tt.toComplete)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+		})
+	}
+}
+
+func Test_completeUsernames_timeout(t *testing.T) {
+	origTimeout := completionTimeout
+	completionTimeout = 10 * time.Millisecond
+	t.Cleanup(func() { completionTimeout = origTimeout })
+
+	t.Setenv("GH_CONFIG_DIR", t.TempDir())
+	require.NoError(t, addToHistory(historyPath(), "foo"))
+
+	client, err := api.NewGraphQLClient(api.ClientOptions{
+		Host:      "foo",
+		AuthToken: "bar",
+		Transport: blockingTransport{},
+	})
+	require.NoError(t, err)
+
+	got, directive := completeUsernames(func() (*api.GraphQLClient, error) { return client, nil })(nil, nil, "")
+	assert.Equal(t, []string{"foo"}, got)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+}
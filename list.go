@@ -2,24 +2,101 @@ package main
 
 import (
 	"bytes"
+	"context"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"text/template"
+	"time"
 
 	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/jq"
 	"github.com/cli/go-gh/v2/pkg/jsonpretty"
 	"github.com/cli/go-gh/v2/pkg/tableprinter"
+	"github.com/cli/go-gh/v2/pkg/text"
 	"github.com/shurcooL/githubv4"
 	"github.com/spf13/cobra"
 )
 
 const defaultListLimit = 30
 
+// defaultAvatarSize is the default size, in pixels, of the avatar images
+// linked by the avatarUrl field.
+const defaultAvatarSize = 64
+
+// listLimitEnv is the environment variable overriding defaultListLimit.
+const listLimitEnv = "GH_SPONSORS_LIMIT"
+
+// sponsorsPageSize is the number of sponsors fetched per request. It is the
+// maximum page size allowed by the GitHub GraphQL API.
+const sponsorsPageSize = 100
+
 var listFields = []string{
 	"login",
 	"name",
+	"tier",
+	"amount",
+	"monthlyPriceInCents",
+	"createdAt",
+	"type",
+	"avatarUrl",
+	"privacy",
+	"bio",
+	"company",
+	"location",
+	"isOneTime",
+	"url",
+	"databaseId",
+	"websiteUrl",
+	"twitterUsername",
+	"socials",
+}
+
+var listSortFields = []string{
+	"login",
+	"relevance",
+	"name",
+	"created",
+}
+
+var listSortOrders = []string{
+	"asc",
+	"desc",
+}
+
+var defaultCSVFields = []string{
+	"login",
+	"name",
+}
+
+// listColumnHeaders maps fields to their table column headers, except for the
+// login field whose header depends on the command.
+var listColumnHeaders = map[string]string{
+	"name":                "NAME",
+	"tier":                "TIER",
+	"amount":              "AMOUNT",
+	"monthlyPriceInCents": "MONTHLY",
+	"createdAt":           "SINCE",
+	"type":                "TYPE",
+	"avatarUrl":           "AVATAR",
+	"privacy":             "PRIVACY",
+	"bio":                 "BIO",
+	"company":             "COMPANY",
+	"location":            "LOCATION",
+	"isOneTime":           "ONE-TIME",
+	"url":                 "URL",
+	"databaseId":          "ID",
+	"websiteUrl":          "WEBSITE",
+	"twitterUsername":     "TWITTER",
+	"socials":             "SOCIALS",
 }
 
 var listFieldsMap = func() map[string]struct{} {
@@ -34,182 +111,1768 @@ type ListOptions struct {
 	Client   *api.GraphQLClient
 	IOs      Terminal
 	Prompter Prompter
+	Browser  Browser
 
 	Username  string
 	FieldsRaw string
 	Fields    []string
+	Limit     int
+	All       bool
+	Web       bool
+	OrgOnly   bool
+	UserOnly  bool
+	// CSV and TSV print the columns selected with --fields, or
+	// defaultCSVFields, as comma- or tab-separated values.
+	CSV       bool
+	TSV       bool
+	Template  string
+	JQ        string
+	Sort      string
+	Order     string
+	Reverse   bool
+	Count     bool
+	Me        bool
+	Total     bool
+	Pretty    bool
+	Compact   bool
+	NDJSON    bool
+	Recurring bool
+	OneTime   bool
+	SinceRaw  string
+	Since     time.Time
+	UntilRaw  string
+	Until     time.Time
+	WithinRaw string
+	Within    period
+	Timeout   time.Duration
+	Stdin     bool
+	FailFast  bool
+	Usernames []string
+	Public    bool
+	Private   bool
+	NoHeader  bool
+
+	// ColumnsRaw and Columns hold the table columns selected with --fields.
+	ColumnsRaw string
+	Columns    []string
+
+	// Output is the path of the file to write results to instead of stdout.
+	Output string
+	// Cache is how long the fetched sponsors are cached on disk, and NoCache
+	// makes them fetched afresh regardless.
+	Cache   time.Duration
+	NoCache bool
+	// MaxRetries is the number of times a failed query is retried.
+	MaxRetries int
+	// AvatarSize is the size of the avatar images linked by the avatarUrl field.
+	AvatarSize int
+	// Watch makes the sponsors listed again every Interval until interrupted.
+	Watch    bool
+	Interval time.Duration
+	// Quiet suppresses informational messages on stderr, set by the global
+	// --quiet flag.
+	Quiet bool
+	// Host is the host of the page opened with --web, set from the global
+	// --hostname flag.
+	Host string
+	// NoColor disables colors and hyperlinks even on a terminal supporting
+	// them.
+	NoColor bool
+	// ExitStatus makes listing no sponsor return errSilent, without printing
+	// anything.
+	ExitStatus bool
+	// Now returns the current time, against which --within, the watch
+	// updates and the timeago template function are reckoned. It defaults
+	// to time.Now.
+	Now func() time.Time
 }
 
 func NewCmdList(
-	client *api.GraphQLClient,
+	client clientFunc,
 	ios Terminal,
 	prompter Prompter,
+	browser Browser,
 	runF func(*ListOptions) error,
 ) *cobra.Command {
 	opts := &ListOptions{
-		Client:   client,
 		IOs:      ios,
 		Prompter: prompter,
+		Browser:  browser,
+		Now:      time.Now,
 	}
 
 	cmd := &cobra.Command{
-		Use:     "list [<user>]",
-		Short:   "List sponsors",
-		Long:    `List sponsors of a given user.`,
+		Use:   "list [<user>...]",
+		Short: "List sponsors",
+		Long: `List sponsors of a given user or organization.
+
+The amount and monthlyPriceInCents fields hold the monthly sponsorship amount in
+US dollars and cents, respectively. They are zero for one-time sponsorships, and
+for sponsorships not visible to the viewer.
+
+The name field is an empty string for accounts without a display name, shown as
+"-" in tables on a terminal.
+
+Off a terminal, the table lists just the login of each sponsor, one per line,
+unless --fields selects other columns.
+
+With --template, the Go template is executed for each sponsor, followed by a
+newline, e.g. "{{.Login}} - {{.Name}}". Its fields are Login, Name, Type, Tier,
+AmountInDollars, IsOneTime, OneTimeAmountInDollars, CreatedAt, Privacy, URL,
+Bio, Company, Location, WebsiteURL and TwitterUsername. Like in gh, the
+tablerow function adds a row to a table printed after the last sponsor, and
+timeago, timefmt and truncate format times and text, e.g.
+"{{tablerow .Login (truncate 20 .Name) (timeago .CreatedAt)}}".
+
+Without a username argument, newline-separated usernames piped to standard
+input are listed as with --stdin when the output is a terminal. Scripts, whose
+output is usually not a terminal, must pass --stdin or "-" to read them, so
+that a standard input left open does not block the command.
+
+With --exit-status, the command prints nothing and exits with status 1 when no
+sponsor is listed, and with status 0 otherwise. Errors exit with status 2 or
+higher, see "gh sponsors --help".`,
 		Aliases: []string{"ls"},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 1 {
-				return errors.New("too many arguments")
+				if slices.Contains(args, "-") {
+					return errors.New("cannot use \"-\" with other username arguments")
+				}
+				opts.Usernames = args
+			} else if len(args) == 1 && args[0] == "-" {
+				opts.Stdin = true
 			} else if len(args) == 1 {
 				opts.Username = args[0]
 			}
 
-			if opts.FieldsRaw != "" {
-				fields := strings.Split(opts.FieldsRaw, ",")
-				for _, f := range fields {
-					if _, ok := listFieldsMap[f]; !ok {
-						return fmt.Errorf("unknown JSON field: %q (available fields: %s)", f, strings.Join(listFields, ", "))
-					}
+			if opts.Me && opts.Username != "" {
+				return errors.New("cannot use --me with a username argument")
+			}
+			if opts.Stdin && (opts.Username != "" || opts.Usernames != nil) {
+				return errors.New("cannot use --stdin with a username argument")
+			}
+
+			if err := validateLimit(opts.Limit); err != nil {
+				return err
+			}
+			if cmd.Flags().Changed("limit") && opts.Limit == 0 {
+				// An explicit zero limit is a shorthand for --all.
+				opts.All = true
+			}
+			if opts.AvatarSize <= 0 {
+				return fmt.Errorf("invalid avatar size: %d (must be greater than zero)", opts.AvatarSize)
+			}
+			if opts.MaxRetries < 0 {
+				return fmt.Errorf("invalid max retries: %d (must not be negative)", opts.MaxRetries)
+			}
+			if opts.Cache < 0 {
+				return fmt.Errorf("invalid cache duration: %s (must not be negative)", opts.Cache)
+			}
+			if opts.Interval <= 0 {
+				return fmt.Errorf("invalid interval: %s (must be greater than zero)", opts.Interval)
+			}
+			if cmd.Flags().Changed("interval") && !opts.Watch {
+				return errors.New("cannot use --interval without --watch")
+			}
+
+			if opts.SinceRaw != "" {
+				since, err := time.Parse(time.DateOnly, opts.SinceRaw)
+				if err != nil {
+					return fmt.Errorf("invalid date: %q (expected YYYY-MM-DD)", opts.SinceRaw)
+				}
+				opts.Since = since
+			}
+			if opts.UntilRaw != "" {
+				until, err := time.Parse(time.DateOnly, opts.UntilRaw)
+				if err != nil {
+					return fmt.Errorf("invalid date: %q (expected YYYY-MM-DD)", opts.UntilRaw)
+				}
+				opts.Until = until
+			}
+			if !opts.Since.IsZero() && !opts.Until.IsZero() && opts.Since.After(opts.Until) {
+				return fmt.Errorf("invalid date range: --since %s is after --until %s", opts.SinceRaw, opts.UntilRaw)
+			}
+			if opts.WithinRaw != "" {
+				if opts.SinceRaw != "" || opts.UntilRaw != "" {
+					return errors.New("cannot use --within with --since or --until")
+				}
+				within, err := parsePeriod(opts.WithinRaw)
+				if err != nil {
+					return err
+				}
+				opts.Within = within
+			}
+
+			if !slices.Contains(listSortFields, opts.Sort) {
+				return fmt.Errorf("unknown sort field: %q (available values: %s)", opts.Sort, strings.Join(listSortFields, ", "))
+			}
+			if !slices.Contains(listSortOrders, opts.Order) {
+				return fmt.Errorf("unknown sort order: %q (available values: %s)", opts.Order, strings.Join(listSortOrders, ", "))
+			}
+
+			delimited := opts.CSV || opts.TSV
+
+			if delimited && opts.FieldsRaw != "" {
+				return errors.New("cannot use --csv or --tsv with --json")
+			}
+			if opts.Template != "" && (delimited || opts.FieldsRaw != "") {
+				return errors.New("cannot use --template with --csv, --tsv or --json")
+			}
+			if opts.JQ != "" && (delimited || opts.Template != "") {
+				return errors.New("cannot use --jq with --csv, --tsv or --template")
+			}
+
+			if opts.Watch && (opts.Stdin || opts.Usernames != nil) {
+				return errors.New("cannot watch multiple accounts")
+			}
+			if (opts.Stdin || opts.Usernames != nil) && !canListBatch(opts) {
+				return errors.New("cannot list multiple accounts with --me, --web, --count, --total, --csv, --tsv, --template, --jq or --ndjson")
+			}
+			if opts.NDJSON && (delimited || opts.Template != "" || opts.JQ != "" || opts.Pretty) {
+				return errors.New("cannot use --ndjson with --csv, --tsv, --template, --jq or --pretty")
+			}
+			if opts.ColumnsRaw != "" && (opts.FieldsRaw != "" || opts.Template != "" || opts.JQ != "") {
+				return errors.New("cannot use --fields with --json, --template or --jq")
+			}
+
+			if opts.Count && (delimited || opts.FieldsRaw != "" || opts.NDJSON || opts.Template != "" || opts.JQ != "") {
+				return errors.New("cannot use --count with --csv, --tsv, --json, --ndjson, --template or --jq")
+			}
+			if opts.Total && (opts.Count || delimited || opts.FieldsRaw != "" || opts.NDJSON || opts.Template != "" || opts.JQ != "") {
+				return errors.New("cannot use --total with --count, --csv, --tsv, --json, --ndjson, --template or --jq")
+			}
+			if opts.Output != "" && opts.Web {
+				return errors.New("cannot use --output with --web")
+			}
+			if opts.ExitStatus && (opts.Web || opts.Watch) {
+				return errors.New("cannot use --exit-status with --web or --watch")
+			}
+			if opts.Watch && (opts.Web || opts.Count || opts.Total || delimited || opts.FieldsRaw != "" || opts.NDJSON || opts.Template != "" || opts.JQ != "" || opts.Output != "") {
+				return errors.New("cannot use --watch with --web, --count, --total, --csv, --tsv, --json, --ndjson, --template, --jq or --output")
+			}
+
+			// Parse the template early to report errors before any API call.
+			if opts.Template != "" {
+				if _, err := parseSponsorTemplate(opts.Template); err != nil {
+					return err
 				}
-				opts.Fields = fields
 			}
 
+			fields, err := parseFields(opts.FieldsRaw)
+			if err != nil {
+				return err
+			}
+			opts.Fields = fields
+
+			columns, err := parseColumns(opts.ColumnsRaw)
+			if err != nil {
+				return err
+			}
+			opts.Columns = columns
+
+			opts.Quiet = isQuiet(cmd)
+			opts.Host = webHost(cmd)
+
 			if runF != nil {
 				return runF(opts)
 			}
 
-			return listRun(opts)
+			c, err := client()
+			if err != nil {
+				return err
+			}
+			opts.Client = c
+
+			if err := listRun(cmd.Context(), opts); err != nil {
+				return err
+			}
+
+			// The history only serves completion, so failing to update it
+			// is not worth failing the command.
+			for _, username := range append([]string{opts.Username}, opts.Usernames...) {
+				if username != "" {
+					_ = addToHistory(historyPath(), username)
+				}
+			}
+			return nil
 		},
+		ValidArgsFunction: completeUsernames(client),
 	}
 
 	// We can't use StringSliceVar method since it supports multiple assignments
 	// like: --json a,b --json c
-	cmd.Flags().StringVar(&opts.FieldsRaw, "json", "", "JSON fields")
+	cmd.Flags().StringVar(&opts.FieldsRaw, "json", "", "JSON fields, or \"all\"")
+	_ = cmd.RegisterFlagCompletionFunc("json", completeFields(listFields))
+	cmd.Flags().StringVar(&opts.ColumnsRaw, "fields", "", "Table, CSV or TSV columns to show, or \"all\"")
+	cmd.Flags().BoolVar(&opts.NoHeader, "no-header", false, "Omit the header row of the table output")
+	cmd.Flags().BoolVar(&opts.ExitStatus, "exit-status", false, "Exit with status 1 if no sponsor is listed")
+	cmd.Flags().BoolVar(&opts.NoColor, "no-color", false, "Disable colors and hyperlinks in the table output, also disabled by $NO_COLOR")
+	cmd.Flags().StringVarP(&opts.Output, "output", "o", "", "Write the output to the given file instead of stdout")
+	cmd.Flags().IntVarP(&opts.Limit, "limit", "L", 0, fmt.Sprintf("Maximum number of sponsors to fetch, or 0 for all (default %d, or $%s)", defaultListLimit, listLimitEnv))
+	cmd.Flags().BoolVar(&opts.All, "all", false, "Fetch all sponsors, following pagination")
+	cmd.Flags().DurationVar(&opts.Timeout, "timeout", 0, "Give up fetching sponsors after the given duration, e.g. 30s (default no timeout)")
+	cmd.Flags().IntVar(&opts.AvatarSize, "avatar-size", defaultAvatarSize, "Size in pixels of the avatar images linked by the avatarUrl field")
+	cmd.Flags().DurationVar(&opts.Cache, "cache", 0, fmt.Sprintf("Cache fetched sponsors on disk for the given duration, e.g. 1h (in $%s or the gh cache directory)", cacheDirEnv))
+	cmd.Flags().BoolVar(&opts.NoCache, "no-cache", false, "Ignore cached sponsors and fetch them afresh, still caching them with --cache")
+	cmd.Flags().BoolVar(&opts.Watch, "watch", false, "Keep listing sponsors on an interval, notifying of new ones, until interrupted")
+	cmd.Flags().DurationVar(&opts.Interval, "interval", defaultWatchInterval, "Time between two listings with --watch, e.g. 30s")
+	cmd.Flags().IntVar(&opts.MaxRetries, "max-retries", defaultMaxRetries, "Maximum number of retries on rate limit and server errors")
+	cmd.Flags().BoolVarP(&opts.Web, "web", "w", false, "Open the sponsors page in the browser")
+	cmd.Flags().BoolVar(&opts.OrgOnly, "org-only", false, "Only list organization sponsors")
+	cmd.Flags().BoolVar(&opts.UserOnly, "user-only", false, "Only list user sponsors")
+	cmd.MarkFlagsMutuallyExclusive("org-only", "user-only")
+	cmd.Flags().BoolVar(&opts.Recurring, "recurring", false, "Only list sponsors with a recurring sponsorship")
+	cmd.Flags().BoolVar(&opts.OneTime, "one-time", false, "Only list sponsors with a one-time sponsorship")
+	cmd.MarkFlagsMutuallyExclusive("recurring", "one-time")
+	cmd.Flags().BoolVar(&opts.Public, "public", false, "Only list sponsors with a public sponsorship")
+	cmd.Flags().BoolVar(&opts.Private, "private", false, "Only list sponsors with a private sponsorship (only visible to the sponsored account)")
+	cmd.MarkFlagsMutuallyExclusive("public", "private")
+	cmd.Flags().StringVar(&opts.SinceRaw, "since", "", "Only list sponsors whose sponsorship started on or after the given date (YYYY-MM-DD)")
+	cmd.Flags().StringVar(&opts.UntilRaw, "until", "", "Only list sponsors whose sponsorship started on or before the given date (YYYY-MM-DD)")
+	cmd.Flags().StringVar(&opts.WithinRaw, "within", "", "Only list sponsors whose sponsorship started within the given number of days, weeks or months (e.g. 30d, 2w or 6mo)")
+	cmd.Flags().BoolVar(&opts.CSV, "csv", false, fmt.Sprintf("Output CSV with the fields selected with --fields (default %q)", strings.Join(defaultCSVFields, ",")))
+	cmd.Flags().BoolVar(&opts.TSV, "tsv", false, fmt.Sprintf("Output tab-separated values with the fields selected with --fields (default %q)", strings.Join(defaultCSVFields, ",")))
+	cmd.MarkFlagsMutuallyExclusive("csv", "tsv")
+	cmd.Flags().BoolVar(&opts.Pretty, "pretty", false, "Pretty-print JSON output, even when not on a terminal")
+	cmd.Flags().BoolVar(&opts.Compact, "compact", false, "Print compact JSON output, even on a terminal")
+	cmd.MarkFlagsMutuallyExclusive("pretty", "compact")
+	cmd.Flags().BoolVar(&opts.NDJSON, "ndjson", false, "Output one JSON object per sponsor and line, with the --json fields (default all)")
+	cmd.Flags().StringVarP(&opts.Template, "template", "t", "", "Format each sponsor using a Go template, e.g. \"{{.Login}} - {{.Name}}\"")
+	cmd.Flags().StringVar(&opts.Sort, "sort", "login", fmt.Sprintf("Sort sponsors by field: {%s}", strings.Join(listSortFields, "|")))
+	cmd.Flags().StringVar(&opts.Order, "order", "asc", fmt.Sprintf("Order of sorted sponsors: {%s}", strings.Join(listSortOrders, "|")))
+	cmd.Flags().BoolVar(&opts.Stdin, "stdin", false, "Read newline-separated usernames from standard input and list the sponsors of each (same as \"-\" argument)")
+	cmd.Flags().BoolVar(&opts.FailFast, "fail-fast", false, "Stop listing at the first failing username with --stdin")
+	cmd.Flags().BoolVar(&opts.Me, "me", false, "List sponsors of the authenticated user")
+	cmd.Flags().BoolVar(&opts.Count, "count", false, "Print the number of sponsors only, or of those matching the filters")
+	cmd.Flags().BoolVar(&opts.Total, "total", false, "Print the estimated monthly and one-time sponsorship totals of all sponsors")
+	cmd.Flags().BoolVarP(&opts.Reverse, "reverse", "r", false, "Reverse the order of sorted sponsors")
+	cmd.Flags().StringVarP(&opts.JQ, "jq", "q", "", "Filter JSON output using a jq expression; all fields are available unless --json is given")
 
 	return cmd
 }
 
-func listRun(opts *ListOptions) error {
-	username := opts.Username
+// validateLimit checks the value of the --limit flag.
+func validateLimit(limit int) error {
+	if limit < 0 {
+		return fmt.Errorf("invalid limit: %d (must not be negative)", limit)
+	} else if limit > sponsorsPageSize {
+		return fmt.Errorf("invalid limit: %d (must not exceed %d)", limit, sponsorsPageSize)
+	}
+	return nil
+}
 
-	if username == "" {
-		if !opts.IOs.IsTerminalOutput() {
-			return errors.New("username not provided")
+// allFields is the field list value selecting all of listFields.
+const allFields = "all"
+
+// parseFields parses the comma-separated value of the --json flag. Fields are
+// trimmed, and empty and repeated ones are dropped. The value "all" selects
+// all fields. It returns nil if the value is empty.
+func parseFields(raw string) ([]string, error) {
+	return parseFieldsOf(raw, listFields)
+}
+
+// parseFieldsOf is like parseFields, for a command whose JSON output has the
+// given available fields instead of listFields.
+func parseFieldsOf(raw string, available []string) ([]string, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	var fields []string
+	for _, f := range strings.Split(raw, ",") {
+		f = strings.TrimSpace(f)
+		if f == "" || slices.Contains(fields, f) {
+			continue
+		}
+		if !slices.Contains(available, f) && f != allFields {
+			return nil, fmt.Errorf("unknown JSON field: %q (available fields: %s)", f, strings.Join(available, ", "))
+		}
+		fields = append(fields, f)
+	}
+	if fields == nil {
+		return nil, fmt.Errorf("no JSON fields given (available fields: %s)", strings.Join(available, ", "))
+	}
+	return expandAllFields(fields, available)
+}
+
+// expandAllFields returns all of the available fields if the given fields are
+// just "all", or the given fields otherwise.
+func expandAllFields(fields, available []string) ([]string, error) {
+	if !slices.Contains(fields, allFields) {
+		return fields, nil
+	}
+	if len(fields) > 1 {
+		return nil, fmt.Errorf("cannot combine %q with other fields", allFields)
+	}
+	return slices.Clone(available), nil
+}
+
+// completeFields returns a completion function for flags taking a
+// comma-separated list of the given fields. Fields already in the list are
+// not suggested again, and completion continues after a trailing comma.
+func completeFields(fields []string) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		var prefix, last string
+		var used []string
+		if i := strings.LastIndex(toComplete, ","); i >= 0 {
+			prefix, last = toComplete[:i+1], toComplete[i+1:]
+			used = strings.Split(toComplete[:i], ",")
+		} else {
+			last = toComplete
+		}
+
+		var completions []string
+		for _, f := range fields {
+			if strings.HasPrefix(f, last) && !slices.Contains(used, f) {
+				completions = append(completions, prefix+f)
+			}
+		}
+		return completions, cobra.ShellCompDirectiveNoSpace | cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// parseColumns parses and validates a comma-separated list of table columns.
+// The value "all" selects all fields. It returns nil if the value is empty.
+func parseColumns(raw string) ([]string, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	columns := strings.Split(raw, ",")
+	for _, c := range columns {
+		if _, ok := listFieldsMap[c]; !ok && c != allFields {
+			return nil, fmt.Errorf("unknown field: %q (available fields: %s)", c, strings.Join(listFields, ", "))
+		}
+	}
+	return expandAllFields(columns, listFields)
+}
+
+// effectiveLimit returns the limit to pass to the query functions, given the
+// values of the --limit and --all flags. Without either flag, the limit is read
+// from the environment, falling back to defaultListLimit.
+func effectiveLimit(limit int, all bool, errOut io.Writer) uint {
+	if limit == 0 && !all {
+		return defaultLimit(errOut)
+	}
+	return uint(limit)
+}
+
+// defaultLimit returns the limit set by the listLimitEnv environment variable,
+// or defaultListLimit if it is unset. Invalid values are reported as a warning
+// and ignored.
+func defaultLimit(errOut io.Writer) uint {
+	raw, ok := os.LookupEnv(listLimitEnv)
+	if !ok || raw == "" {
+		return defaultListLimit
+	}
+
+	limit, err := strconv.Atoi(raw)
+	if err == nil && limit == 0 {
+		err = errors.New("must be greater than zero")
+	}
+	if err == nil {
+		err = validateLimit(limit)
+	}
+	if err != nil {
+		fmt.Fprintf(errOut, "warning: ignoring invalid %s value %q, using %d\n", listLimitEnv, raw, defaultListLimit)
+		return defaultListLimit
+	}
+	return uint(limit)
+}
+
+// resolveUsername returns the given username, or prompts for one if it is
+// empty and the output is a terminal. The prompt defaults to the login of the
+// authenticated user, when it can be fetched.
+func resolveUsername(client *api.GraphQLClient, ios Terminal, prompter Prompter, username string) (string, error) {
+	if username != "" {
+		return username, nil
+	}
+	if !ios.IsTerminalOutput() {
+		return "", errors.New("username not provided")
+	}
+	// The prompt works without a default, so the error is not worth reporting.
+	login, _ := viewerLogin(context.Background(), client)
+	return prompter.Input("Which user do you want to target?", login)
+}
+
+// viewerLogin fetches the login of the authenticated user.
+func viewerLogin(ctx context.Context, client *api.GraphQLClient) (string, error) {
+	var query struct {
+		Viewer struct {
+			Login githubv4.String
 		}
-		value, err := opts.Prompter.Input("Which user do you want to target?", "")
+	}
+
+	if err := client.QueryWithContext(ctx, "ViewerLogin", &query, nil); err != nil {
+		return "", err
+	}
+	return string(query.Viewer.Login), nil
+}
+
+// openInBrowser opens the given URL in the browser, letting the user know on
+// a terminal. Off a terminal, the URL is printed instead.
+func openInBrowser(ios Terminal, browser Browser, url string) error {
+	if !ios.IsTerminalOutput() {
+		fmt.Fprintln(ios.Out(), url)
+		return nil
+	}
+	fmt.Fprintf(ios.ErrOut(), "Opening %s in your browser.\n", url)
+	return browser.Browse(url)
+}
+
+// fileTerminal is a non-interactive Terminal whose output goes to a file, so
+// that TTY-only output like pretty JSON and summary lines is left out.
+type fileTerminal struct {
+	Terminal
+	out io.Writer
+}
+
+func (t *fileTerminal) Out() io.Writer {
+	return t.out
+}
+
+func (t *fileTerminal) IsTerminalOutput() bool {
+	return false
+}
+
+func (t *fileTerminal) IsColorEnabled() bool {
+	return false
+}
+
+// outputFile is written to a temporary file next to its path, which replaces
+// the file at path on commit. A failed command leaves an existing file intact.
+type outputFile struct {
+	*os.File
+	path string
+}
+
+// createOutputFile creates a temporary file in the directory of path, with the
+// permissions of the existing file at path, if any.
+func createOutputFile(path string) (*outputFile, error) {
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return nil, err
+	}
+	mode := os.FileMode(0o644)
+	if fi, err := os.Stat(path); err == nil {
+		mode = fi.Mode().Perm()
+	}
+	if err := f.Chmod(mode); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return nil, err
+	}
+	return &outputFile{File: f, path: path}, nil
+}
+
+// commit closes the temporary file and renames it to the output path.
+func (f *outputFile) commit() error {
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	if err := os.Rename(f.Name(), f.path); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return nil
+}
+
+// discard closes and removes the temporary file.
+func (f *outputFile) discard() {
+	f.Close()
+	os.Remove(f.Name())
+}
+
+// noColorTerminal is a Terminal with colors disabled, for --no-color.
+type noColorTerminal struct {
+	Terminal
+}
+
+func (t *noColorTerminal) IsColorEnabled() bool {
+	return false
+}
+
+func listRun(ctx context.Context, opts *ListOptions) (err error) {
+	if opts.Now == nil {
+		o := *opts
+		o.Now = time.Now
+		opts = &o
+	}
+	if opts.NoColor {
+		o := *opts
+		o.IOs = &noColorTerminal{Terminal: opts.IOs}
+		opts = &o
+	}
+
+	var usernames []string
+	batch := opts.Stdin || opts.Usernames != nil
+	if opts.Stdin {
+		usernames, err = readUsernames(opts.IOs.In())
+		if err != nil {
+			return err
+		}
+	} else if opts.Usernames != nil {
+		usernames = opts.Usernames
+	} else if opts.Username == "" && canListBatch(opts) && opts.IOs.IsTerminalOutput() && stdinPiped(opts.IOs.In()) {
+		// Usernames piped without a username argument are listed as with
+		// --stdin, falling back to the usual resolution if there are none.
+		// Off a terminal, reading them needs --stdin, since a script may leave
+		// the standard input open without ever writing to it.
+		usernames, err = readUsernames(opts.IOs.In())
 		if err != nil {
 			return err
 		}
-		username = value
+		batch = len(usernames) > 0
 	}
 
-	sponsors, err := listSponsors(opts.Client, username, defaultListLimit)
+	var username string
+	if !batch {
+		username = opts.Username
+		if opts.Me {
+			login, err := viewerLogin(ctx, opts.Client)
+			if err != nil {
+				return err
+			}
+			username = login
+		}
+
+		username, err = resolveUsername(opts.Client, opts.IOs, opts.Prompter, username)
+		if err != nil {
+			return err
+		}
+
+		if opts.Web {
+			return openInBrowser(opts.IOs, opts.Browser, webURL(opts.Host, "sponsors/"+username))
+		}
+	}
+
+	// written is the number of sponsors written to the output file, if any,
+	// to confirm on a terminal once the file is closed.
+	written := -1
+	if opts.Output != "" {
+		// The deferred function checks the named err result, so it must not
+		// be shadowed here.
+		f, ferr := createOutputFile(opts.Output)
+		if ferr != nil {
+			return fmt.Errorf("failed to create output file %s: %w", opts.Output, ferr)
+		}
+		ios, path, quiet := opts.IOs, opts.Output, opts.Quiet
+		defer func() {
+			if err != nil {
+				f.discard()
+				return
+			}
+			if cerr := f.commit(); cerr != nil {
+				err = fmt.Errorf("failed to write output file %s: %w", path, cerr)
+			}
+			if err == nil && written >= 0 && ios.IsTerminalOutput() && !quiet {
+				if written == 1 {
+					fmt.Fprintf(ios.ErrOut(), "wrote 1 sponsor to %s\n", path)
+				} else {
+					fmt.Fprintf(ios.ErrOut(), "wrote %d sponsors to %s\n", written, path)
+				}
+			}
+		}()
+
+		o := *opts
+		o.IOs = &fileTerminal{Terminal: opts.IOs, out: f}
+		opts = &o
+	}
+
+	if batch {
+		return listBatchRun(ctx, opts, usernames)
+	}
+	if opts.Watch {
+		return listWatchRun(ctx, opts, username)
+	}
+
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	if opts.Count {
+		var total int
+		if hasSponsorFilter(opts) {
+			// The total count covers all sponsors, so the matching ones are
+			// fetched and counted instead.
+			sponsors, _, err := fetchSponsors(ctx, opts, username)
+			if err != nil {
+				return err
+			}
+			total = len(sponsors)
+		} else {
+			total, err = countSponsors(opts.Client, username)
+			if err != nil {
+				return err
+			}
+		}
+
+		if total == 0 && opts.ExitStatus {
+			return errSilent
+		}
+		if !opts.IOs.IsTerminalOutput() {
+			fmt.Fprintln(opts.IOs.Out(), total)
+		} else if total == 1 {
+			fmt.Fprintln(opts.IOs.Out(), "1 sponsor")
+		} else {
+			fmt.Fprintf(opts.IOs.Out(), "%d sponsors\n", total)
+		}
+		return nil
+	}
+
+	sponsors, total, err := fetchSponsors(ctx, opts, username)
 	if err != nil {
 		return err
 	}
+	if len(sponsors) == 0 && opts.ExitStatus {
+		// Like grep's, the exit status alone tells that nothing is listed.
+		return errSilent
+	}
+	written = len(sponsors)
 
-	if opts.Fields != nil {
-		data := make([]any, 0, len(sponsors))
+	if opts.Total {
+		monthly, oneTime := 0, 0
 		for _, sponsor := range sponsors {
-			m := make(map[string]any, 2)
-			for _, f := range opts.Fields {
-				switch f {
-				case "login":
-					m["login"] = sponsor.Login
-				case "name":
-					m["name"] = sponsor.Name
-				}
-			}
-			data = append(data, m)
+			monthly += sponsor.AmountInDollars
+			oneTime += sponsor.OneTimeAmountInDollars
+		}
+		fmt.Fprintf(opts.IOs.Out(), "Estimated monthly total: %s\n", formatDollars(monthly))
+		fmt.Fprintf(opts.IOs.Out(), "One-time total: %s\n", formatDollars(oneTime))
+		return nil
+	}
+
+	if opts.CSV || opts.TSV {
+		fields := opts.Columns
+		if fields == nil {
+			fields = defaultCSVFields
+		}
+		if opts.CSV {
+			return printSponsorsCSV(opts.IOs.Out(), sponsors, fields, opts.NoHeader)
+		}
+		return printSponsorsTSV(opts.IOs.Out(), sponsors, fields, opts.NoHeader)
+	}
+	if opts.Template != "" {
+		return printSponsorsTemplate(opts.IOs, sponsors, opts.Template, opts.Now)
+	}
+	if opts.JQ != "" {
+		fields := opts.Fields
+		if fields == nil {
+			fields = listFields
+		}
+		return printSponsorsJQ(opts.IOs, sponsors, fields, opts.JQ, prettyJSON(opts.IOs, opts.Pretty, opts.Compact))
+	}
+	if opts.NDJSON {
+		fields := opts.Fields
+		if fields == nil {
+			fields = listFields
+		}
+		return printSponsorsNDJSON(opts.IOs.Out(), sponsors, fields)
+	}
+
+	if opts.Fields == nil && opts.IOs.IsTerminalOutput() && len(sponsors) > 0 && !opts.Quiet {
+		fmt.Fprintf(opts.IOs.ErrOut(), "%s\n", showingHint(opts, len(sponsors), total))
+	}
+
+	return printSponsors(opts.IOs, sponsors, opts.Fields, opts.Columns, prettyJSON(opts.IOs, opts.Pretty, opts.Compact), opts.NoHeader, opts.Quiet, "SPONSOR", "no sponsor found")
+}
+
+// fetchSponsors fetches the sponsors of the given user or organization, then
+// sorts and filters them as requested by the list options. The total number of
+// sponsors is returned along with the fetched ones.
+func fetchSponsors(ctx context.Context, opts *ListOptions, username string) ([]sponsor, int, error) {
+	order := opts.Order
+	if opts.Reverse {
+		if order == "desc" {
+			order = "asc"
+		} else {
+			order = "desc"
+		}
+	}
+
+	// Totals and counts are computed over all sponsors, unless capped with
+	// --limit.
+	all := opts.All || opts.Total || opts.Count
+	limit := effectiveLimit(opts.Limit, all, opts.IOs.ErrOut())
+
+	// The API cannot order sponsors by name or sponsorship creation date, nor
+	// filter them, so they are sorted and filtered here instead. All sponsors
+	// are fetched then, and the limit is applied to the sorted and filtered
+	// ones.
+	clientSort := opts.Sort == "name" || opts.Sort == "created"
+	fetchLimit := limit
+	if clientSort || hasSponsorFilter(opts) {
+		fetchLimit = 0
+	}
+
+	ctx = withCache(ctx, cacheOptions{TTL: opts.Cache, Refresh: opts.NoCache})
+	sponsors, total, err := listSponsors(ctx, opts.Client, username, fetchLimit, sponsorOrder(opts.Sort, order), opts.AvatarSize, opts.MaxRetries, opts.IOs.ErrOut())
+	if errors.Is(err, context.DeadlineExceeded) && opts.Timeout > 0 {
+		return nil, 0, fmt.Errorf("request timed out after %s", opts.Timeout)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if clientSort {
+		sortSponsors(sponsors, opts.Sort, order == "desc")
+	}
+
+	if opts.OrgOnly {
+		sponsors = filterSponsorsByType(sponsors, sponsorTypeOrganization)
+	} else if opts.UserOnly {
+		sponsors = filterSponsorsByType(sponsors, sponsorTypeUser)
+	}
+
+	if opts.Recurring {
+		sponsors = filterSponsorsByPayment(sponsors, false)
+	} else if opts.OneTime {
+		sponsors = filterSponsorsByPayment(sponsors, true)
+	}
+
+	if !opts.Since.IsZero() || !opts.Until.IsZero() {
+		sponsors = filterSponsorsByDate(sponsors, opts.Since, opts.Until)
+	} else if opts.Within != (period{}) {
+		// The period is relative to each fetch, so that it moves along with
+		// --watch.
+		sponsors = filterSponsorsByDate(sponsors, opts.Within.before(opts.Now()), time.Time{})
+	}
+
+	if opts.Public {
+		sponsors = filterSponsorsByPrivacy(sponsors, sponsorshipPrivacyPublic)
+	} else if opts.Private {
+		sponsors = filterSponsorsByPrivacy(sponsors, sponsorshipPrivacyPrivate)
+	}
+
+	if limit > 0 && uint(len(sponsors)) > limit {
+		sponsors = sponsors[:limit]
+	}
+	return sponsors, total, nil
+}
+
+// showingHint describes how many of the total sponsors are shown. The total
+// counts all sponsors, so it is left out when filters drop some of them.
+func showingHint(opts *ListOptions, shown, total int) string {
+	if hasSponsorFilter(opts) {
+		return fmt.Sprintf("Showing %d matching sponsors", shown)
+	}
+	return fmt.Sprintf("Showing %d of %d sponsors", shown, total)
+}
+
+// hasSponsorFilter reports whether the list options filter the fetched
+// sponsors, by type, payment, date or privacy.
+func hasSponsorFilter(opts *ListOptions) bool {
+	return opts.OrgOnly || opts.UserOnly ||
+		opts.Recurring || opts.OneTime ||
+		!opts.Since.IsZero() || !opts.Until.IsZero() || opts.Within != (period{}) ||
+		opts.Public || opts.Private
+}
+
+// sponsorOrder returns the API ordering for the given values of the --sort and
+// --order flags. Sort fields not supported by the API fall back to ordering by
+// login.
+func sponsorOrder(sort, order string) githubv4.SponsorOrder {
+	o := githubv4.SponsorOrder{
+		Field:     githubv4.SponsorOrderFieldLogin,
+		Direction: githubv4.OrderDirectionAsc,
+	}
+	if sort == "relevance" {
+		o.Field = githubv4.SponsorOrderFieldRelevance
+	}
+	if order == "desc" {
+		o.Direction = githubv4.OrderDirectionDesc
+	}
+	return o
+}
+
+// sortSponsors sorts the sponsors by the given field, either "name" or
+// "created", keeping the original order of sponsors with equal values.
+// Sponsors without a name are sorted by their login instead.
+func sortSponsors(sponsors []sponsor, field string, desc bool) {
+	slices.SortStableFunc(sponsors, func(a, b sponsor) int {
+		if desc {
+			a, b = b, a
+		}
+		switch field {
+		case "name":
+			return strings.Compare(sortName(a), sortName(b))
+		case "created":
+			return a.CreatedAt.Compare(b.CreatedAt)
 		}
+		return 0
+	})
+}
+
+func sortName(s sponsor) string {
+	if s.Name == "" {
+		return strings.ToLower(s.Login)
+	}
+	return strings.ToLower(s.Name)
+}
+
+func filterSponsorsByType(sponsors []sponsor, typ string) []sponsor {
+	result := make([]sponsor, 0, len(sponsors))
+	for _, s := range sponsors {
+		if s.Type == typ {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// filterSponsorsByPayment returns the sponsors with a one-time sponsorship, or
+// the others if oneTime is false. Sponsorships not visible to the viewer are
+// considered recurring.
+func filterSponsorsByPayment(sponsors []sponsor, oneTime bool) []sponsor {
+	result := make([]sponsor, 0, len(sponsors))
+	for _, s := range sponsors {
+		if s.IsOneTime == oneTime {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// filterSponsorsByPrivacy returns the sponsors whose sponsorship has the given
+// privacy level. Sponsorships not visible to the viewer have no privacy level
+// and are left out.
+func filterSponsorsByPrivacy(sponsors []sponsor, privacy string) []sponsor {
+	result := make([]sponsor, 0, len(sponsors))
+	for _, s := range sponsors {
+		if s.Privacy == privacy {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// period is a number of days or months, as given to --within.
+type period struct {
+	days   int
+	months int
+}
+
+// periodPattern matches a period like 30d, 2w or 6mo.
+var periodPattern = regexp.MustCompile(`^([0-9]+)(d|w|mo)$`)
 
-		buf := &bytes.Buffer{}
-		if err := json.NewEncoder(buf).Encode(data); err != nil {
+// parsePeriod parses a period of days (d), weeks (w) or months (mo).
+func parsePeriod(s string) (period, error) {
+	invalid := fmt.Errorf("invalid period: %q (expected a positive number of days, weeks or months, e.g. 30d, 2w or 6mo)", s)
+	m := periodPattern.FindStringSubmatch(s)
+	if m == nil {
+		return period{}, invalid
+	}
+	n, err := strconv.Atoi(m[1])
+	if err != nil || n == 0 {
+		return period{}, invalid
+	}
+	switch m[2] {
+	case "w":
+		return period{days: 7 * n}, nil
+	case "mo":
+		return period{months: n}, nil
+	default:
+		return period{days: n}, nil
+	}
+}
+
+// before returns the time the period before t.
+func (p period) before(t time.Time) time.Time {
+	return t.AddDate(0, -p.months, -p.days)
+}
+
+// filterSponsorsByDate returns the sponsors whose sponsorship started between
+// the since and until dates, both inclusive. A zero date leaves that end of the
+// window open. Sponsorships not visible to the viewer have no start date and
+// are left out.
+func filterSponsorsByDate(sponsors []sponsor, since, until time.Time) []sponsor {
+	result := make([]sponsor, 0, len(sponsors))
+	for _, s := range sponsors {
+		if s.CreatedAt.IsZero() {
+			continue
+		}
+		if !since.IsZero() && s.CreatedAt.Before(since) {
+			continue
+		}
+		if !until.IsZero() && !s.CreatedAt.Before(until.AddDate(0, 0, 1)) {
+			continue
+		}
+		result = append(result, s)
+	}
+	return result
+}
+
+// printSponsorsCSV writes the given fields of the sponsors as CSV, preceded by
+// a header line with the field names unless noHeader is set.
+func printSponsorsCSV(w io.Writer, sponsors []sponsor, fields []string, noHeader bool) error {
+	cw := csv.NewWriter(w)
+	if !noHeader {
+		if err := cw.Write(fields); err != nil {
+			return err
+		}
+	}
+	for _, sponsor := range sponsors {
+		if err := cw.Write(sponsorRecord(sponsor, fields)); err != nil {
 			return err
 		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
 
-		if opts.IOs.IsTerminalOutput() {
-			jsonpretty.Format(opts.IOs.Out(), buf, "  ", true)
-			return nil
+// tsvReplacer replaces the tabs and line breaks of TSV values with spaces, so
+// that values need no quoting.
+var tsvReplacer = strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ", "\r", " ")
+
+// printSponsorsTSV writes the given fields of the sponsors as tab-separated
+// values, preceded by a header line with the field names unless noHeader is
+// set. Values are not quoted; their tabs and line breaks become spaces.
+func printSponsorsTSV(w io.Writer, sponsors []sponsor, fields []string, noHeader bool) error {
+	if !noHeader {
+		if _, err := fmt.Fprintln(w, strings.Join(fields, "\t")); err != nil {
+			return err
+		}
+	}
+	for _, sponsor := range sponsors {
+		record := sponsorRecord(sponsor, fields)
+		for i, v := range record {
+			record[i] = tsvReplacer.Replace(v)
+		}
+		if _, err := fmt.Fprintln(w, strings.Join(record, "\t")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sponsorRecord returns the given fields of the sponsor as strings, for CSV
+// and TSV output.
+func sponsorRecord(s sponsor, fields []string) []string {
+	record := make([]string, 0, len(fields))
+	for _, f := range fields {
+		record = append(record, fmt.Sprint(s.field(f)))
+	}
+	return record
+}
+
+// sponsorsData returns the given fields of the sponsors, ready to be encoded as
+// a JSON array.
+func sponsorsData(sponsors []sponsor, fields []string) []any {
+	data := make([]any, 0, len(sponsors))
+	for _, sponsor := range sponsors {
+		m := make(map[string]any, len(fields))
+		for _, f := range fields {
+			m[f] = sponsor.field(f)
 		}
+		data = append(data, m)
+	}
+	return data
+}
 
-		io.Copy(opts.IOs.Out(), buf)
+// encodeSponsors encodes the given fields of the sponsors as a JSON array.
+func encodeSponsors(sponsors []sponsor, fields []string) (*bytes.Buffer, error) {
+	return encodeJSON(sponsorsData(sponsors, fields))
+}
+
+// encodeJSON encodes the given data as JSON.
+func encodeJSON(data any) (*bytes.Buffer, error) {
+	buf := &bytes.Buffer{}
+	if err := json.NewEncoder(buf).Encode(data); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// printJSON prints the given data as JSON, pretty-printed if pretty is set.
+func printJSON(ios Terminal, data any, pretty bool) error {
+	buf, err := encodeJSON(data)
+	if err != nil {
+		return err
+	}
+
+	if pretty {
+		jsonpretty.Format(ios.Out(), buf, "  ", ios.IsTerminalOutput())
 		return nil
 	}
 
+	_, err = io.Copy(ios.Out(), buf)
+	return err
+}
+
+// printSponsorsNDJSON writes the given fields of the sponsors as
+// newline-delimited JSON, one compact object per line.
+func printSponsorsNDJSON(w io.Writer, sponsors []sponsor, fields []string) error {
+	enc := json.NewEncoder(w)
+	for _, sponsor := range sponsors {
+		m := make(map[string]any, len(fields))
+		for _, f := range fields {
+			m[f] = sponsor.field(f)
+		}
+		if err := enc.Encode(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// printSponsorsJQ filters the given fields of the sponsors, encoded as a JSON
+// array, through the jq expression. JSON results are indented if pretty is
+// set, and colored on a terminal.
+func printSponsorsJQ(ios Terminal, sponsors []sponsor, fields []string, expr string, pretty bool) error {
+	buf, err := encodeSponsors(sponsors, fields)
+	if err != nil {
+		return err
+	}
+
+	if pretty {
+		return jq.EvaluateFormatted(buf, ios.Out(), expr, "  ", ios.IsTerminalOutput())
+	}
+	return jq.Evaluate(buf, ios.Out(), expr)
+}
+
+// parseSponsorTemplate parses the Go template executed for each sponsor.
+func parseSponsorTemplate(tmpl string) (*template.Template, error) {
+	t, err := template.New("").Funcs(sponsorTemplateFuncs(nil, time.Now)).Parse(tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+	return t, nil
+}
+
+// sponsorTemplateFuncs returns the functions of --template, named after those
+// of gh's templates. tablerow adds a row to the given table, and timeago,
+// timefmt and truncate format values, timeago relative to now.
+func sponsorTemplateFuncs(table tableprinter.TablePrinter, now func() time.Time) template.FuncMap {
+	return template.FuncMap{
+		"tablerow": func(fields ...any) string {
+			for _, f := range fields {
+				table.AddField(fmt.Sprint(f))
+			}
+			table.EndRow()
+			return ""
+		},
+		"timeago": func(t time.Time) string {
+			return text.RelativeTimeAgo(now(), t)
+		},
+		"timefmt": func(format string, t time.Time) string {
+			return t.Format(format)
+		},
+		"truncate": text.Truncate,
+	}
+}
+
+// printSponsorsTemplate executes the given Go template once for each sponsor,
+// against its sponsor struct, followed by a newline unless it prints nothing.
+// Rows added with tablerow are rendered as a table after the last sponsor.
+func printSponsorsTemplate(ios Terminal, sponsors []sponsor, tmpl string, now func() time.Time) error {
+	t, err := parseSponsorTemplate(tmpl)
+	if err != nil {
+		return err
+	}
+
+	width, _, _ := ios.Size()
+	table := tableprinter.New(ios.Out(), ios.IsTerminalOutput(), width)
+	t.Funcs(sponsorTemplateFuncs(table, now))
+
+	buf := &bytes.Buffer{}
+	for _, s := range sponsors {
+		buf.Reset()
+		if err := t.Execute(buf, s); err != nil {
+			return fmt.Errorf("failed to execute template: %w", err)
+		}
+		if buf.Len() > 0 {
+			fmt.Fprintln(ios.Out(), buf.String())
+		}
+	}
+	return table.Render()
+}
+
+// prettyJSON reports whether JSON output should be pretty-printed. It is on a
+// terminal, unless overridden by --pretty or --compact.
+func prettyJSON(ios Terminal, pretty, compact bool) bool {
+	if pretty || compact {
+		return pretty
+	}
+	return ios.IsTerminalOutput()
+}
+
+// printSponsors prints the given fields of the sponsors as JSON, or the given
+// columns as a table if there are no fields. On a terminal, emptyMessage is
+// printed on stderr if there are no sponsors, unless quiet is set.
+func printSponsors(ios Terminal, sponsors []sponsor, fields, columns []string, pretty, noHeader, quiet bool, loginHeader, emptyMessage string) error {
+	if fields != nil {
+		return printJSON(ios, sponsorsData(sponsors, fields), pretty)
+	}
+
 	if len(sponsors) == 0 {
-		if opts.IOs.IsTerminalOutput() {
-			fmt.Fprintln(opts.IOs.ErrOut(), "no sponsor found")
-			return nil
+		if ios.IsTerminalOutput() && !quiet {
+			fmt.Fprintln(ios.ErrOut(), emptyMessage)
 		}
 		return nil
 	}
 
-	width, _, _ := opts.IOs.Size()
-	headers := []string{"SPONSOR"}
-	table := tableprinter.New(opts.IOs.Out(), opts.IOs.IsTerminalOutput(), width)
-	table.AddHeader(headers)
+	if columns == nil {
+		columns = defaultColumns(sponsors, ios.IsTerminalOutput())
+	}
+
+	width, _, _ := ios.Size()
+	headers := make([]string, 0, len(columns))
+	for _, c := range columns {
+		if c == "login" {
+			headers = append(headers, loginHeader)
+		} else {
+			headers = append(headers, listColumnHeaders[c])
+		}
+	}
+	table := tableprinter.New(ios.Out(), ios.IsTerminalOutput(), width)
+	if !noHeader {
+		table.AddHeader(headers)
+	}
 	for _, sponsor := range sponsors {
-		table.AddField(sponsor.Login)
+		for _, c := range columns {
+			addSponsorField(table, ios, sponsor, c)
+		}
 		table.EndRow()
 	}
 
-	err = table.Render()
-	if err != nil {
-		return err
+	return table.Render()
+}
+
+// defaultColumns returns the table columns shown when none are selected. Off a
+// terminal, it is just the login, one per line, for scripts. On a terminal, it
+// is the login and the name, followed by the tier, the monthly amount and the
+// start date if any of the sponsors has them.
+func defaultColumns(sponsors []sponsor, tty bool) []string {
+	if !tty {
+		return []string{"login"}
 	}
 
-	return nil
+	hasTier, hasAmount, hasCreatedAt := false, false, false
+	for _, sponsor := range sponsors {
+		if sponsor.Tier != "" {
+			hasTier = true
+		}
+		if sponsor.MonthlyPriceInCents != 0 {
+			hasAmount = true
+		}
+		if !sponsor.CreatedAt.IsZero() {
+			hasCreatedAt = true
+		}
+	}
+
+	columns := []string{"login", "name"}
+	if hasTier {
+		columns = append(columns, "tier")
+	}
+	if hasAmount {
+		columns = append(columns, "monthlyPriceInCents")
+	}
+	if hasCreatedAt {
+		columns = append(columns, "createdAt")
+	}
+	return columns
 }
 
+// Sponsorship privacy levels, as exposed by the privacy JSON field.
+const (
+	sponsorshipPrivacyPublic  = "public"
+	sponsorshipPrivacyPrivate = "private"
+)
+
+// Sponsor account types, as reported by the GraphQL __typename field.
+const (
+	sponsorTypeUser         = "User"
+	sponsorTypeOrganization = "Organization"
+)
+
 type sponsor struct {
 	Login string
 	Name  string
+	// Type is either "User" or "Organization".
+	Type string
+	// Tier is the name of the sponsorship tier. It is empty when the tier is
+	// not visible to the viewer or the sponsorship has a custom amount.
+	Tier string
+	// AmountInDollars is the monthly sponsorship amount. It is zero for
+	// one-time sponsorships.
+	AmountInDollars int
+	// MonthlyPriceInCents is the same amount as AmountInDollars, in cents.
+	MonthlyPriceInCents int
+	// IsOneTime reports whether the sponsorship is a one-time payment. It is
+	// false when the sponsorship is not visible to the viewer.
+	IsOneTime bool
+	// OneTimeAmountInDollars is the amount of a one-time sponsorship. It is
+	// zero for recurring sponsorships.
+	OneTimeAmountInDollars int
+	// CreatedAt is when the sponsorship started. It is the zero time when the
+	// sponsorship is not visible to the viewer.
+	CreatedAt time.Time
+	// Privacy is the privacy level of the sponsorship, either "public" or
+	// "private". It is empty when the sponsorship is not visible to the viewer.
+	Privacy string
+	// URL is the account's profile page, and DatabaseID its stable identifier
+	// in the GitHub REST API.
+	URL        string
+	DatabaseID int
+	// Bio, Company, Location, WebsiteURL and TwitterUsername are taken from the
+	// account's profile. Bio, Company and TwitterUsername are always empty for
+	// organizations.
+	Bio             string
+	Company         string
+	Location        string
+	WebsiteURL      string
+	TwitterUsername string
+	// Socials are the social accounts listed on the account's profile. They
+	// are always empty for organizations.
+	Socials []socialAccount
+	// AvatarURL is the URL of the account's avatar image. It is only shown in
+	// the table when selected with --fields.
+	AvatarURL string
 }
 
-func listSponsors(client *api.GraphQLClient, username string, limit uint) ([]sponsor, error) {
-	var query struct {
-		User struct {
-			Sponsors struct {
-				Edges []struct {
-					Node struct {
-						User struct {
-							Login githubv4.String
-							Name  githubv4.String
-						} `graphql:"... on User"`
-						Org struct {
-							Login githubv4.String
-							Name  githubv4.String
-						} `graphql:"... on Organization"`
-					}
-				}
-			} `graphql:"sponsors(first: $limit, orderBy: { direction: ASC, field: LOGIN })"`
-		} `graphql:"user(login: $login)"`
+// field returns the value of the given JSON field.
+func (s sponsor) field(name string) any {
+	switch name {
+	case "login":
+		return s.Login
+	case "name":
+		return s.Name
+	case "tier":
+		return s.Tier
+	case "amount":
+		return s.AmountInDollars
+	case "monthlyPriceInCents":
+		return s.MonthlyPriceInCents
+	case "createdAt":
+		if s.CreatedAt.IsZero() {
+			return ""
+		}
+		return s.CreatedAt.Format(time.RFC3339)
+	case "type":
+		return s.Type
+	case "avatarUrl":
+		return s.AvatarURL
+	case "privacy":
+		return s.Privacy
+	case "bio":
+		return s.Bio
+	case "company":
+		return s.Company
+	case "location":
+		return s.Location
+	case "isOneTime":
+		return s.IsOneTime
+	case "url":
+		return s.URL
+	case "databaseId":
+		return s.DatabaseID
+	case "websiteUrl":
+		return s.WebsiteURL
+	case "twitterUsername":
+		return s.TwitterUsername
+	case "socials":
+		if s.Socials == nil {
+			return []socialAccount{}
+		}
+		return s.Socials
 	}
+	return nil
+}
 
-	variables := map[string]any{
-		"login": githubv4.String(username),
-		"limit": githubv4.Int(limit),
+// column returns the table cell of the given field.
+func (s sponsor) column(name string) string {
+	switch name {
+	case "amount":
+		return formatDollars(s.AmountInDollars)
+	case "monthlyPriceInCents":
+		return formatCents(s.MonthlyPriceInCents)
+	case "createdAt":
+		if s.CreatedAt.IsZero() {
+			return ""
+		}
+		return s.CreatedAt.Format(time.DateOnly)
+	case "socials":
+		socials := make([]string, 0, len(s.Socials))
+		for _, a := range s.Socials {
+			socials = append(socials, fmt.Sprintf("%s (%s)", a.DisplayName, a.Provider))
+		}
+		return strings.Join(socials, ", ")
 	}
+	return fmt.Sprint(s.field(name))
+}
 
-	err := client.Query("UserSponsorList", &query, variables)
-	if err != nil {
-		return nil, err
+// addSponsorField adds the table cell of the given field of the sponsor. When
+// colors are enabled, logins are highlighted and link to the sponsor's profile,
+// names are dimmed, and tiers are colored by amount, warmer for higher ones,
+// with one-time tiers dimmed.
+func addSponsorField(table tableprinter.TablePrinter, ios Terminal, s sponsor, name string) {
+	cell := s.cell(name, ios.IsTerminalOutput())
+	if cell == "" || !ios.IsColorEnabled() {
+		table.AddField(cell)
+		return
+	}
+	switch {
+	case name == "login" && s.URL != "":
+		link := hyperlink(s.URL)
+		table.AddField(cell, tableprinter.WithColor(func(t string) string {
+			return colorize(colorBold, link(t))
+		}))
+	case name == "login":
+		table.AddField(cell, tableprinter.WithColor(func(t string) string {
+			return colorize(colorBold, t)
+		}))
+	case name == "name":
+		table.AddField(cell, tableprinter.WithColor(func(t string) string {
+			return colorize(colorDim, t)
+		}))
+	case name == "tier":
+		table.AddField(cell, tableprinter.WithColor(tierColor(s)))
+	default:
+		table.AddField(cell)
+	}
+}
+
+// hyperlink returns a function turning a table cell into an OSC 8 hyperlink to
+// the given URL, leaving the cell padding out of the link. Terminals without
+// hyperlink support show the plain text.
+func hyperlink(url string) func(string) string {
+	return func(t string) string {
+		text := strings.TrimRight(t, " ")
+		return "\x1b]8;;" + url + "\x1b\\" + text + "\x1b]8;;\x1b\\" + t[len(text):]
+	}
+}
+
+// ANSI escape sequences used to color table cells.
+const (
+	colorReset  = "\x1b[0m"
+	colorBold   = "\x1b[1m"
+	colorDim    = "\x1b[2m"
+	colorRed    = "\x1b[31m"
+	colorGreen  = "\x1b[32m"
+	colorYellow = "\x1b[33m"
+)
+
+// Monthly amounts, in US dollars, from which tiers are colored warmer.
+const (
+	warmTierAmount = 25
+	hotTierAmount  = 100
+)
+
+// tierColor returns a function coloring the tier of the sponsor.
+func tierColor(s sponsor) func(string) string {
+	var code string
+	switch {
+	case s.IsOneTime:
+		code = colorDim
+	case s.AmountInDollars >= hotTierAmount:
+		code = colorRed
+	case s.AmountInDollars >= warmTierAmount:
+		code = colorYellow
+	default:
+		code = colorGreen
 	}
+	return func(t string) string {
+		return colorize(code, t)
+	}
+}
 
-	result := make([]sponsor, 0, len(query.User.Sponsors.Edges))
-	for _, edge := range query.User.Sponsors.Edges {
-		if edge.Node.User.Login != "" {
-			result = append(result, sponsor{
-				Login: string(edge.Node.User.Login),
-				Name:  string(edge.Node.User.Name),
-			})
-		} else if edge.Node.Org.Login != "" {
-			result = append(result, sponsor{
-				Login: string(edge.Node.Org.Login),
-				Name:  string(edge.Node.Org.Name),
-			})
+// colorize wraps a table cell in the given ANSI escape sequence, leaving the
+// cell padding out of it.
+func colorize(code, t string) string {
+	text := strings.TrimRight(t, " ")
+	return code + text + colorReset + t[len(text):]
+}
+
+// noNamePlaceholder is the table cell shown on a terminal for the name of
+// sponsors without a display name. Their name JSON field is an empty string.
+const noNamePlaceholder = "-"
+
+// cell returns the table cell of the given field, on a terminal or not.
+func (s sponsor) cell(name string, tty bool) string {
+	if name == "name" && s.Name == "" && tty {
+		return noNamePlaceholder
+	}
+	return s.column(name)
+}
+
+// socialAccount is a social account listed on a profile.
+type socialAccount struct {
+	// Provider is the lowercase name of the social network, e.g. "mastodon",
+	// or "generic" for other websites.
+	Provider    string `json:"provider"`
+	DisplayName string `json:"displayName"`
+}
+
+// formatDollars formats a whole amount of dollars with comma-separated
+// thousands, e.g. "$1,250".
+func formatDollars(dollars int) string {
+	sign := ""
+	if dollars < 0 {
+		sign = "-"
+		dollars = -dollars
+	}
+
+	digits := strconv.Itoa(dollars)
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
 		}
+		b.WriteRune(d)
 	}
-	return result, nil
+	return sign + "$" + b.String()
+}
+
+// formatCents formats an amount in cents as dollars, omitting the fraction
+// when it is a whole number. Zero amounts are formatted as an empty string.
+func formatCents(cents int) string {
+	if cents == 0 {
+		return ""
+	}
+	if cents%100 == 0 {
+		return fmt.Sprintf("$%d", cents/100)
+	}
+	return fmt.Sprintf("$%d.%02d", cents/100, cents%100)
+}
+
+// ErrUserNotFound is returned when the target login does not resolve to an
+// existing account.
+var ErrUserNotFound = errors.New("no such user")
+
+// userNotFoundError returns the error reported when the repository owner
+// queried for the given login is null. Every user and organization is
+// sponsorable, so this means that the account does not exist.
+func userNotFoundError(login string) error {
+	return fmt.Errorf("%w: %q", ErrUserNotFound, login)
+}
+
+// translateQueryError unwraps rate limit errors from the request error
+// reporting them, leaving any other error untouched.
+func translateQueryError(err error) error {
+	var rlErr *rateLimitError
+	if errors.As(err, &rlErr) {
+		return rlErr
+	}
+	return err
+}
+
+// sponsorship holds the fields queried for a sponsorship. Private
+// sponsorships and tiers are only visible to the sponsor, the sponsorable and
+// their admins.
+type sponsorship struct {
+	IsOneTimePayment githubv4.Boolean
+	CreatedAt        githubv4.DateTime
+	PrivacyLevel     githubv4.SponsorshipPrivacy
+	Tier             *struct {
+		Name                  githubv4.String
+		MonthlyPriceInDollars githubv4.Int
+		MonthlyPriceInCents   githubv4.Int
+	}
+}
+
+// applyTo sets the sponsorship fields of the sponsor.
+func (sp *sponsorship) applyTo(s *sponsor) {
+	s.CreatedAt = sp.CreatedAt.Time
+	s.IsOneTime = bool(sp.IsOneTimePayment)
+	s.Privacy = strings.ToLower(string(sp.PrivacyLevel))
+	if sp.Tier != nil {
+		s.Tier = string(sp.Tier.Name)
+		if sp.IsOneTimePayment {
+			s.OneTimeAmountInDollars = int(sp.Tier.MonthlyPriceInDollars)
+		} else {
+			s.AmountInDollars = int(sp.Tier.MonthlyPriceInDollars)
+			s.MonthlyPriceInCents = int(sp.Tier.MonthlyPriceInCents)
+		}
+	}
+}
+
+// sponsorEntity is implemented by the types holding the fields queried for
+// each node of a sponsor connection.
+type sponsorEntity interface {
+	toSponsor() sponsor
+}
+
+// sponsorNode holds the fields queried for each sponsor, whether it is a user
+// or an organization.
+type sponsorNode struct {
+	Login      githubv4.String
+	Name       githubv4.String
+	AvatarURL  githubv4.String `graphql:"avatarUrl(size: $size)"`
+	URL        githubv4.URI
+	DatabaseID githubv4.Int
+	// SponsorshipsAsSponsor holds the sponsorship of the listed account by
+	// this sponsor, unless it is not visible to the viewer. Unlike the
+	// sponsorshipForViewerAsSponsorable field, it does not depend on who the
+	// viewer is.
+	SponsorshipsAsSponsor struct {
+		Nodes []sponsorship
+	} `graphql:"sponsorshipsAsSponsor(first: 1, maintainerLogins: [$login])"`
+}
+
+func (n sponsorNode) toSponsor() sponsor {
+	s := sponsor{
+		Login:      string(n.Login),
+		Name:       string(n.Name),
+		AvatarURL:  string(n.AvatarURL),
+		URL:        uriString(n.URL),
+		DatabaseID: int(n.DatabaseID),
+	}
+	if len(n.SponsorshipsAsSponsor.Nodes) > 0 {
+		n.SponsorshipsAsSponsor.Nodes[0].applyTo(&s)
+	}
+	return s
+}
+
+// uriString returns the given URI as a string, or an empty string if it is
+// missing.
+func uriString(u githubv4.URI) string {
+	if u.URL == nil {
+		return ""
+	}
+	return u.String()
+}
+
+// sponsorConnection is a page of a connection whose nodes are users or
+// organizations, like the sponsors or sponsoring connections.
+type sponsorConnection[N sponsorEntity] struct {
+	Edges []struct {
+		Node struct {
+			Typename githubv4.String `graphql:"__typename"`
+			User     N               `graphql:"... on User"`
+			Org      N               `graphql:"... on Organization"`
+			// UserProfile and OrgProfile hold the profile fields, which differ
+			// between users and organizations.
+			UserProfile struct {
+				Bio             githubv4.String
+				Company         githubv4.String
+				Location        githubv4.String
+				WebsiteURL      githubv4.URI
+				TwitterUsername githubv4.String
+				SocialAccounts  struct {
+					Nodes []struct {
+						Provider    githubv4.SocialAccountProvider
+						DisplayName githubv4.String
+					}
+				} `graphql:"socialAccounts(first: 5)"`
+			} `graphql:"... on User"`
+			OrgProfile struct {
+				Location   githubv4.String
+				WebsiteURL githubv4.URI
+			} `graphql:"... on Organization"`
+		}
+	}
+	PageInfo struct {
+		EndCursor   githubv4.String
+		HasNextPage githubv4.Boolean
+	}
+	TotalCount githubv4.Int
+}
+
+// maxSponsorPages is the maximum number of pages fetched by paginateSponsors,
+// guarding against connections that never report their last page. It is
+// replaced in tests.
+var maxSponsorPages = 1000
+
+// paginateSponsors calls fetch for consecutive pages of a sponsor connection
+// until it is exhausted, or maxSponsorPages pages were fetched, which is
+// reported as a warning on errOut. A non-zero limit caps the number of returned
+// sponsors. The total number of nodes in the connection is returned along with
+// the fetched sponsors.
+func paginateSponsors[N sponsorEntity](limit uint, errOut io.Writer, fetch func(first githubv4.Int, after *githubv4.String) (*sponsorConnection[N], error)) ([]sponsor, int, error) {
+	var after *githubv4.String
+	var total int
+
+	result := make([]sponsor, 0)
+	for page := 1; ; page++ {
+		pageSize := uint(sponsorsPageSize)
+		if limit > 0 && limit-uint(len(result)) < pageSize {
+			pageSize = limit - uint(len(result))
+		}
+
+		conn, err := fetch(githubv4.Int(pageSize), after)
+		if err != nil {
+			return nil, 0, err
+		}
+		total = int(conn.TotalCount)
+
+		for _, edge := range conn.Edges {
+			var s sponsor
+			switch edge.Node.Typename {
+			case sponsorTypeUser:
+				s = edge.Node.User.toSponsor()
+				s.Bio = string(edge.Node.UserProfile.Bio)
+				s.Company = string(edge.Node.UserProfile.Company)
+				s.Location = string(edge.Node.UserProfile.Location)
+				s.WebsiteURL = uriString(edge.Node.UserProfile.WebsiteURL)
+				s.TwitterUsername = string(edge.Node.UserProfile.TwitterUsername)
+				for _, a := range edge.Node.UserProfile.SocialAccounts.Nodes {
+					s.Socials = append(s.Socials, socialAccount{
+						Provider:    strings.ToLower(string(a.Provider)),
+						DisplayName: string(a.DisplayName),
+					})
+				}
+			case sponsorTypeOrganization:
+				s = edge.Node.Org.toSponsor()
+				s.Location = string(edge.Node.OrgProfile.Location)
+				s.WebsiteURL = uriString(edge.Node.OrgProfile.WebsiteURL)
+			default:
+				continue
+			}
+			s.Type = string(edge.Node.Typename)
+			result = append(result, s)
+		}
+
+		if !conn.PageInfo.HasNextPage {
+			break
+		}
+		if limit > 0 && uint(len(result)) >= limit {
+			break
+		}
+		if page >= maxSponsorPages {
+			fmt.Fprintf(errOut, "warning: stopped after fetching %d pages, %d of %d sponsors\n", page, len(result), total)
+			break
+		}
+		after = githubv4.NewString(conn.PageInfo.EndCursor)
+	}
+	return result, total, nil
+}
+
+// listSponsors fetches the sponsors of the given user or organization,
+// following the connection's pagination until it is exhausted. A non-zero
+// limit caps the number of returned sponsors. The total number of sponsors is
+// returned along with the fetched ones. Avatar URLs link to images of the given
+// size, or of their original size if it is zero. Each page is retried up to
+// maxRetries times on rate limit and server errors. Warnings are written to
+// errOut.
+func listSponsors(ctx context.Context, client *api.GraphQLClient, username string, limit uint, orderBy githubv4.SponsorOrder, avatarSize, maxRetries int, errOut io.Writer) ([]sponsor, int, error) {
+	var size *githubv4.Int
+	if avatarSize > 0 {
+		size = githubv4.NewInt(githubv4.Int(avatarSize))
+	}
+
+	return paginateSponsors(limit, errOut, func(first githubv4.Int, after *githubv4.String) (*sponsorConnection[sponsorNode], error) {
+		var query struct {
+			RepositoryOwner *struct {
+				Sponsorable struct {
+					Sponsors sponsorConnection[sponsorNode] `graphql:"sponsors(first: $first, after: $after, orderBy: $orderBy)"`
+				} `graphql:"... on Sponsorable"`
+			} `graphql:"repositoryOwner(login: $login)"`
+		}
+
+		variables := map[string]any{
+			"login":   githubv4.String(username),
+			"first":   first,
+			"after":   after,
+			"orderBy": orderBy,
+			"size":    size,
+		}
+
+		if err := client.QueryWithContext(withMaxRetries(ctx, maxRetries, errOut), "SponsorList", &query, variables); err != nil {
+			return nil, fmt.Errorf("failed to list sponsors for %q: %w", username, translateQueryError(err))
+		}
+		if query.RepositoryOwner == nil {
+			return nil, userNotFoundError(username)
+		}
+		return &query.RepositoryOwner.Sponsorable.Sponsors, nil
+	})
 }
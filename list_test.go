@@ -2,17 +2,24 @@ package main
 
 import (
 	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/cli/go-gh/v2/pkg/api"
 	"github.com/cli/go-gh/v2/pkg/prompter"
 	"github.com/google/shlex"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -42,198 +49,3112 @@ func TestNewCmdList(t *testing.T) {
 			cli:  "--json name,login johndoe",
 			wants: ListOptions{
 				Username: "johndoe",
+				Fields:   []string{"name", "login"},
 			},
+		}, {
+			name: "limit",
+			cli:  "--limit 10 johndoe",
+			wants: ListOptions{
+				Username: "johndoe",
+				Limit:    10,
+			},
+		}, {
+			name: "limit shorthand",
+			cli:  "-L 1 johndoe",
+			wants: ListOptions{
+				Username: "johndoe",
+				Limit:    1,
+			},
+		}, {
+			name: "limit zero",
+			cli:  "--limit 0 johndoe",
+			wants: ListOptions{
+				Username: "johndoe",
+				Limit:    0,
+				All:      true,
+			},
+		}, {
+			name: "limit max",
+			cli:  "--limit 100 johndoe",
+			wants: ListOptions{
+				Username: "johndoe",
+				Limit:    100,
+			},
+		}, {
+			name: "all",
+			cli:  "--all johndoe",
+			wants: ListOptions{
+				Username: "johndoe",
+				All:      true,
+			},
+		}, {
+			name: "all with limit",
+			cli:  "--all --limit 50 johndoe",
+			wants: ListOptions{
+				Username: "johndoe",
+				All:      true,
+				Limit:    50,
+			},
+		}, {
+			name: "org only",
+			cli:  "--org-only johndoe",
+			wants: ListOptions{
+				Username: "johndoe",
+				OrgOnly:  true,
+			},
+		}, {
+			name: "user only",
+			cli:  "--user-only johndoe",
+			wants: ListOptions{
+				Username: "johndoe",
+				UserOnly: true,
+			},
+		}, {
+			name:    "failure org only and user only",
+			cli:     "--org-only --user-only johndoe",
+			wantErr: "if any flags in the group [org-only user-only] are set none of the others can be; [org-only user-only] were all set",
+		}, {
+			name: "pretty",
+			cli:  "--json login --pretty johndoe",
+			wants: ListOptions{
+				Username: "johndoe",
+				Fields:   []string{"login"},
+				Pretty:   true,
+			},
+		}, {
+			name: "compact",
+			cli:  "--json login --compact johndoe",
+			wants: ListOptions{
+				Username: "johndoe",
+				Fields:   []string{"login"},
+				Compact:  true,
+			},
+		}, {
+			name: "fields",
+			cli:  "--fields login,tier,type johndoe",
+			wants: ListOptions{
+				Username: "johndoe",
+				Columns:  []string{"login", "tier", "type"},
+			},
+		}, {
+			name:    "failure fields unknown field",
+			cli:     "--fields login,blah johndoe",
+			wantErr: "unknown field: \"blah\" (available fields: login, name, tier, amount, monthlyPriceInCents, createdAt, type, avatarUrl, privacy, bio, company, location, isOneTime, url, databaseId, websiteUrl, twitterUsername, socials)",
+		}, {
+			name:    "failure fields and json",
+			cli:     "--fields login --json login johndoe",
+			wantErr: "cannot use --fields with --json, --template or --jq",
+		}, {
+			name: "ndjson",
+			cli:  "--ndjson --json login johndoe",
+			wants: ListOptions{
+				Username: "johndoe",
+				Fields:   []string{"login"},
+				NDJSON:   true,
+			},
+		}, {
+			name:    "failure ndjson and csv",
+			cli:     "--ndjson --csv johndoe",
+			wantErr: "cannot use --ndjson with --csv, --tsv, --template, --jq or --pretty",
+		}, {
+			name:    "failure negative max retries",
+			cli:     "--max-retries -1 johndoe",
+			wantErr: "invalid max retries: -1 (must not be negative)",
+		}, {
+			name: "output",
+			cli:  "--json login -o sponsors.json johndoe",
+			wants: ListOptions{
+				Username: "johndoe",
+				Fields:   []string{"login"},
+				Output:   "sponsors.json",
+			},
+		}, {
+			name:    "failure output and web",
+			cli:     "--output sponsors.json --web johndoe",
+			wantErr: "cannot use --output with --web",
+		}, {
+			name: "watch",
+			cli:  "--watch johndoe",
+			wants: ListOptions{
+				Username: "johndoe",
+				Watch:    true,
+				Interval: time.Minute,
+			},
+		}, {
+			name: "watch interval",
+			cli:  "--watch --interval 30s johndoe",
+			wants: ListOptions{
+				Username: "johndoe",
+				Watch:    true,
+				Interval: 30 * time.Second,
+			},
+		}, {
+			name:    "failure interval without watch",
+			cli:     "--interval 30s johndoe",
+			wantErr: "cannot use --interval without --watch",
+		}, {
+			name:    "failure invalid interval",
+			cli:     "--watch --interval 0s johndoe",
+			wantErr: "invalid interval: 0s (must be greater than zero)",
+		}, {
+			name:    "failure watch and json",
+			cli:     "--watch --json login johndoe",
+			wantErr: "cannot use --watch with --web, --count, --total, --csv, --tsv, --json, --ndjson, --template, --jq or --output",
+		}, {
+			name:    "failure watch multiple accounts",
+			cli:     "--watch johndoe janedoe",
+			wantErr: "cannot watch multiple accounts",
+		}, {
+			name: "exit status",
+			cli:  "--exit-status johndoe",
+			wants: ListOptions{
+				Username:   "johndoe",
+				ExitStatus: true,
+			},
+		}, {
+			name:    "failure exit status and watch",
+			cli:     "--exit-status --watch johndoe",
+			wantErr: "cannot use --exit-status with --web or --watch",
+		}, {
+			name: "json all",
+			cli:  "--json all johndoe",
+			wants: ListOptions{
+				Username: "johndoe",
+				Fields:   listFields,
+			},
+		}, {
+			name: "fields all",
+			cli:  "--fields all johndoe",
+			wants: ListOptions{
+				Username: "johndoe",
+				Columns:  listFields,
+			},
+		}, {
+			name:    "failure fields all with other fields",
+			cli:     "--fields all,login johndoe",
+			wantErr: "cannot combine \"all\" with other fields",
+		}, {
+			name: "no header",
+			cli:  "--no-header johndoe",
+			wants: ListOptions{
+				Username: "johndoe",
+				NoHeader: true,
+			},
+		}, {
+			name: "cache",
+			cli:  "--cache 1h --no-cache johndoe",
+			wants: ListOptions{
+				Username: "johndoe",
+				Cache:    time.Hour,
+				NoCache:  true,
+			},
+		}, {
+			name:    "failure negative cache",
+			cli:     "--cache -1h johndoe",
+			wantErr: "invalid cache duration: -1h0m0s (must not be negative)",
+		}, {
+			name: "recurring",
+			cli:  "--recurring johndoe",
+			wants: ListOptions{
+				Username:  "johndoe",
+				Recurring: true,
+			},
+		}, {
+			name: "one-time",
+			cli:  "--one-time johndoe",
+			wants: ListOptions{
+				Username: "johndoe",
+				OneTime:  true,
+			},
+		}, {
+			name:    "failure recurring and one-time",
+			cli:     "--recurring --one-time johndoe",
+			wantErr: "if any flags in the group [recurring one-time] are set none of the others can be; [one-time recurring] were all set",
+		}, {
+			name: "since",
+			cli:  "--since 2024-01-31 johndoe",
+			wants: ListOptions{
+				Username: "johndoe",
+				Since:    time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+			},
+		}, {
+			name:    "failure invalid since",
+			cli:     "--since 31/01/2024 johndoe",
+			wantErr: "invalid date: \"31/01/2024\" (expected YYYY-MM-DD)",
+		}, {
+			name: "since and until",
+			cli:  "--since 2024-01-01 --until 2024-01-01 johndoe",
+			wants: ListOptions{
+				Username: "johndoe",
+				Since:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				Until:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		}, {
+			name:    "failure invalid until",
+			cli:     "--until 2024-13-01 johndoe",
+			wantErr: "invalid date: \"2024-13-01\" (expected YYYY-MM-DD)",
+		}, {
+			name:    "failure since after until",
+			cli:     "--since 2024-02-01 --until 2024-01-31 johndoe",
+			wantErr: "invalid date range: --since 2024-02-01 is after --until 2024-01-31",
+		}, {
+			name: "within",
+			cli:  "--within 2w johndoe",
+			wants: ListOptions{
+				Username: "johndoe",
+				Within:   period{days: 14},
+			},
+		}, {
+			name:    "failure invalid within",
+			cli:     "--within 30 johndoe",
+			wantErr: "invalid period: \"30\" (expected a positive number of days, weeks or months, e.g. 30d, 2w or 6mo)",
+		}, {
+			name:    "failure within and since",
+			cli:     "--within 30d --since 2024-01-01 johndoe",
+			wantErr: "cannot use --within with --since or --until",
+		}, {
+			name: "stdin",
+			cli:  "--stdin",
+			wants: ListOptions{
+				Stdin: true,
+			},
+		}, {
+			name: "stdin dash argument",
+			cli:  "- --fail-fast",
+			wants: ListOptions{
+				Stdin:    true,
+				FailFast: true,
+			},
+		}, {
+			name: "multiple usernames",
+			cli:  "--json login alice bob carol",
+			wants: ListOptions{
+				Usernames: []string{"alice", "bob", "carol"},
+				Fields:    []string{"login"},
+			},
+		}, {
+			name:    "failure multiple usernames with dash",
+			cli:     "alice -",
+			wantErr: "cannot use \"-\" with other username arguments",
+		}, {
+			name:    "failure multiple usernames with csv",
+			cli:     "--csv alice bob",
+			wantErr: "cannot list multiple accounts with --me, --web, --count, --total, --csv, --tsv, --template, --jq or --ndjson",
+		}, {
+			name:    "failure stdin with username",
+			cli:     "--stdin johndoe",
+			wantErr: "cannot use --stdin with a username argument",
+		}, {
+			name:    "failure stdin with count",
+			cli:     "--stdin --count",
+			wantErr: "cannot list multiple accounts with --me, --web, --count, --total, --csv, --tsv, --template, --jq or --ndjson",
+		}, {
+			name: "public",
+			cli:  "--public johndoe",
+			wants: ListOptions{
+				Username: "johndoe",
+				Public:   true,
+			},
+		}, {
+			name:    "failure public and private",
+			cli:     "--public --private johndoe",
+			wantErr: "if any flags in the group [public private] are set none of the others can be; [private public] were all set",
+		}, {
+			name:    "failure invalid avatar size",
+			cli:     "--avatar-size 0 johndoe",
+			wantErr: "invalid avatar size: 0 (must be greater than zero)",
+		}, {
+			name:    "failure pretty and compact",
+			cli:     "--json login --pretty --compact johndoe",
+			wantErr: "if any flags in the group [pretty compact] are set none of the others can be; [compact pretty] were all set",
+		}, {
+			name: "csv",
+			cli:  "--csv johndoe",
+			wants: ListOptions{
+				Username: "johndoe",
+				CSV:      true,
+			},
+		}, {
+			name: "csv with fields",
+			cli:  "--csv --fields login,tier,amount johndoe",
+			wants: ListOptions{
+				Username: "johndoe",
+				CSV:      true,
+				Columns:  []string{"login", "tier", "amount"},
+			},
+		}, {
+			name: "csv with fields before the flag",
+			cli:  "--fields login,tier --csv johndoe",
+			wants: ListOptions{
+				Username: "johndoe",
+				CSV:      true,
+				Columns:  []string{"login", "tier"},
+			},
+		}, {
+			name:    "failure csv unknown field",
+			cli:     "--csv --fields login,blah johndoe",
+			wantErr: "unknown field: \"blah\" (available fields: login, name, tier, amount, monthlyPriceInCents, createdAt, type, avatarUrl, privacy, bio, company, location, isOneTime, url, databaseId, websiteUrl, twitterUsername, socials)",
+		}, {
+			name:    "failure csv and json",
+			cli:     "--csv --json login johndoe",
+			wantErr: "cannot use --csv or --tsv with --json",
+		}, {
+			name: "tsv",
+			cli:  "--tsv johndoe",
+			wants: ListOptions{
+				Username: "johndoe",
+				TSV:      true,
+			},
+		}, {
+			name: "tsv with fields",
+			cli:  "--tsv --fields login,bio --no-header johndoe",
+			wants: ListOptions{
+				Username: "johndoe",
+				TSV:      true,
+				Columns:  []string{"login", "bio"},
+				NoHeader: true,
+			},
+		}, {
+			name:    "failure tsv and json",
+			cli:     "--tsv --json login johndoe",
+			wantErr: "cannot use --csv or --tsv with --json",
+		}, {
+			name:    "failure csv and tsv",
+			cli:     "--csv --tsv johndoe",
+			wantErr: "if any flags in the group [csv tsv] are set none of the others can be; [csv tsv] were all set",
+		}, {
+			name: "template",
+			cli:  "--template '{{.Login}} - {{.Name}}' johndoe",
+			wants: ListOptions{
+				Username: "johndoe",
+				Template: "{{.Login}} - {{.Name}}",
+			},
+		}, {
+			name:    "failure malformed template",
+			cli:     "--template '{{.Login' johndoe",
+			wantErr: "failed to parse template: template: :1: unclosed action",
+		}, {
+			name:    "failure template and json",
+			cli:     "--template '{{.}}' --json login johndoe",
+			wantErr: "cannot use --template with --csv, --tsv or --json",
+		}, {
+			name:    "failure template and csv",
+			cli:     "-t '{{.}}' --csv johndoe",
+			wantErr: "cannot use --template with --csv, --tsv or --json",
+		}, {
+			name: "jq",
+			cli:  "--jq '.[].login' johndoe",
+			wants: ListOptions{
+				Username: "johndoe",
+				JQ:       ".[].login",
+			},
+		}, {
+			name: "jq with json",
+			cli:  "-q '.[].login' --json login johndoe",
+			wants: ListOptions{
+				Username: "johndoe",
+				Fields:   []string{"login"},
+				JQ:       ".[].login",
+			},
+		}, {
+			name:    "failure jq and csv",
+			cli:     "--jq . --csv johndoe",
+			wantErr: "cannot use --jq with --csv, --tsv or --template",
+		}, {
+			name: "sort and order",
+			cli:  "--sort created --order desc johndoe",
+			wants: ListOptions{
+				Username: "johndoe",
+				Sort:     "created",
+				Order:    "desc",
+			},
+		}, {
+			name: "reverse",
+			cli:  "--reverse johndoe",
+			wants: ListOptions{
+				Username: "johndoe",
+				Reverse:  true,
+			},
+		}, {
+			name: "count",
+			cli:  "--count johndoe",
+			wants: ListOptions{
+				Username: "johndoe",
+				Count:    true,
+			},
+		}, {
+			name:    "failure count and json",
+			cli:     "--count --json login johndoe",
+			wantErr: "cannot use --count with --csv, --tsv, --json, --ndjson, --template or --jq",
+		}, {
+			name: "me",
+			cli:  "--me",
+			wants: ListOptions{
+				Me: true,
+			},
+		}, {
+			name:    "failure me with username",
+			cli:     "--me johndoe",
+			wantErr: "cannot use --me with a username argument",
+		}, {
+			name: "total",
+			cli:  "--total johndoe",
+			wants: ListOptions{
+				Username: "johndoe",
+				Total:    true,
+			},
+		}, {
+			name:    "failure total and count",
+			cli:     "--total --count johndoe",
+			wantErr: "cannot use --total with --count, --csv, --tsv, --json, --ndjson, --template or --jq",
+		}, {
+			name:    "failure unknown sort field",
+			cli:     "--sort blah johndoe",
+			wantErr: "unknown sort field: \"blah\" (available values: login, relevance, name, created)",
+		}, {
+			name:    "failure unknown sort order",
+			cli:     "--order up johndoe",
+			wantErr: "unknown sort order: \"up\" (available values: asc, desc)",
+		}, {
+			name:    "failure limit above max",
+			cli:     "--limit 101 johndoe",
+			wantErr: "invalid limit: 101 (must not exceed 100)",
+		}, {
+			name:    "failure negative limit",
+			cli:     "--limit -1 johndoe",
+			wantErr: "invalid limit: -1 (must not be negative)",
 		}, {
 			name:    "failure json",
 			cli:     "--json blah johndoe",
-			wantErr: "unknown JSON field: \"blah\" (available fields: login, name)",
+			wantErr: "unknown JSON field: \"blah\" (available fields: login, name, tier, amount, monthlyPriceInCents, createdAt, type, avatarUrl, privacy, bio, company, location, isOneTime, url, databaseId, websiteUrl, twitterUsername, socials)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			argv, err := shlex.Split(tt.cli)
+			assert.NoError(t, err)
+
+			var listOpts *ListOptions
+			cmd := NewCmdList(
+				nil, nil, nil, nil,
+				func(opts *ListOptions) error {
+					listOpts = opts
+					return nil
+				},
+			)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			_, err = cmd.ExecuteC()
+			if tt.wantErr != "" {
+				require.Equal(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			require.Equal(t, tt.wants.Username, listOpts.Username)
+			require.Equal(t, tt.wants.Limit, listOpts.Limit)
+			require.Equal(t, tt.wants.All, listOpts.All)
+			require.Equal(t, tt.wants.OrgOnly, listOpts.OrgOnly)
+			require.Equal(t, tt.wants.UserOnly, listOpts.UserOnly)
+			require.Equal(t, tt.wants.CSV, listOpts.CSV)
+			require.Equal(t, tt.wants.Fields, listOpts.Fields)
+			require.Equal(t, tt.wants.TSV, listOpts.TSV)
+			require.Equal(t, tt.wants.Template, listOpts.Template)
+			require.Equal(t, tt.wants.JQ, listOpts.JQ)
+
+			wantSort, wantOrder := tt.wants.Sort, tt.wants.Order
+			if wantSort == "" {
+				wantSort = "login"
+			}
+			if wantOrder == "" {
+				wantOrder = "asc"
+			}
+			require.Equal(t, wantSort, listOpts.Sort)
+			require.Equal(t, wantOrder, listOpts.Order)
+			require.Equal(t, tt.wants.Reverse, listOpts.Reverse)
+			require.Equal(t, tt.wants.Count, listOpts.Count)
+			require.Equal(t, tt.wants.Me, listOpts.Me)
+			require.Equal(t, tt.wants.Total, listOpts.Total)
+			require.Equal(t, tt.wants.Pretty, listOpts.Pretty)
+			require.Equal(t, tt.wants.Compact, listOpts.Compact)
+			require.Equal(t, tt.wants.Columns, listOpts.Columns)
+			require.Equal(t, tt.wants.NDJSON, listOpts.NDJSON)
+			require.Equal(t, tt.wants.Recurring, listOpts.Recurring)
+			require.Equal(t, tt.wants.OneTime, listOpts.OneTime)
+			require.Equal(t, tt.wants.Since, listOpts.Since)
+			require.Equal(t, tt.wants.Until, listOpts.Until)
+			require.Equal(t, tt.wants.Within, listOpts.Within)
+			require.Equal(t, tt.wants.Stdin, listOpts.Stdin)
+			require.Equal(t, tt.wants.FailFast, listOpts.FailFast)
+			require.Equal(t, tt.wants.Usernames, listOpts.Usernames)
+			require.Equal(t, tt.wants.Public, listOpts.Public)
+			require.Equal(t, tt.wants.Private, listOpts.Private)
+			require.Equal(t, tt.wants.Output, listOpts.Output)
+			require.Equal(t, tt.wants.NoHeader, listOpts.NoHeader)
+			require.Equal(t, tt.wants.Cache, listOpts.Cache)
+			require.Equal(t, tt.wants.NoCache, listOpts.NoCache)
+			require.Equal(t, tt.wants.Watch, listOpts.Watch)
+			require.Equal(t, tt.wants.ExitStatus, listOpts.ExitStatus)
+			if tt.wants.Interval != 0 {
+				require.Equal(t, tt.wants.Interval, listOpts.Interval)
+			}
+		})
+	}
+}
+
+func Test_parseFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "empty",
+			raw:  "",
+		}, {
+			name: "normal",
+			raw:  "login,name",
+			want: []string{"login", "name"},
+		}, {
+			name: "empty entries",
+			raw:  "login,,name,",
+			want: []string{"login", "name"},
+		}, {
+			name: "duplicates",
+			raw:  "name,login,name,login",
+			want: []string{"name", "login"},
+		}, {
+			name: "whitespace",
+			raw:  " login , name",
+			want: []string{"login", "name"},
+		}, {
+			name: "all",
+			raw:  "all",
+			want: listFields,
+		}, {
+			name:    "all with other fields",
+			raw:     "login,all",
+			wantErr: "cannot combine \"all\" with other fields",
+		}, {
+			name:    "only separators",
+			raw:     ", ,",
+			wantErr: "no JSON fields given (available fields: " + strings.Join(listFields, ", ") + ")",
+		}, {
+			name:    "unknown field",
+			raw:     "login,foo",
+			wantErr: "unknown JSON field: \"foo\" (available fields: " + strings.Join(listFields, ", ") + ")",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseFields(tt.raw)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_completeFields(t *testing.T) {
+	tests := []struct {
+		name       string
+		toComplete string
+		want       []string
+	}{
+		{
+			name:       "empty",
+			toComplete: "",
+			want:       []string{"login", "name", "location"},
+		}, {
+			name:       "prefix",
+			toComplete: "lo",
+			want:       []string{"login", "location"},
+		}, {
+			name:       "after comma",
+			toComplete: "login,",
+			want:       []string{"login,name", "login,location"},
+		}, {
+			name:       "prefix after comma",
+			toComplete: "name,lo",
+			want:       []string{"name,login", "name,location"},
+		}, {
+			name:       "no match",
+			toComplete: "login,foo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			complete := completeFields([]string{"login", "name", "location"})
+			got, directive := complete(nil, nil, tt.toComplete)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, cobra.ShellCompDirectiveNoSpace|cobra.ShellCompDirectiveNoFileComp, directive)
+		})
+	}
+}
+
+func TestNewCmdList_jsonCompletion(t *testing.T) {
+	cmd := NewCmdList(nil, nil, nil, nil, nil)
+	complete, ok := cmd.GetFlagCompletionFunc("json")
+	require.True(t, ok)
+
+	got, _ := complete(cmd, nil, "")
+	assert.Equal(t, listFields, got)
+
+	got, _ = complete(cmd, nil, "login,na")
+	assert.Equal(t, []string{"login,name"}, got)
+}
+
+func Test_listRun(t *testing.T) {
+	defaultHTTPStubs := func(t *testing.T, mt *mockTransport) {
+		mt.respBody = `
+				{
+					"data": {
+						"repositoryOwner": {
+							"sponsors": {
+								"edges": [
+									{
+										"node": {
+											"__typename": "User",
+											"login": "foo",
+											"name": "Foo",
+											"avatarUrl": "https://avatars.githubusercontent.com/u/1"
+										}
+									},
+									{
+										"node": {
+											"__typename": "User",
+											"login": "bar",
+											"name": "Bar"
+										}
+									}
+								],
+								"totalCount": 142
+							}
+						}
+					}
+				}`
+	}
+
+	socialsHTTPStubs := func(_ *testing.T, mt *mockTransport) {
+		mt.respBody = `{"data":{"repositoryOwner":{"sponsors":{"edges":[
+			{"node":{"__typename":"User","login":"foo","socialAccounts":{"nodes":[
+				{"provider":"MASTODON","displayName":"@foo@mastodon.social"},
+				{"provider":"GENERIC","displayName":"foo.dev"}
+			]}}},
+			{"node":{"__typename":"Organization","login":"acme"}}
+		]}}}}`
+	}
+
+	tierHTTPStubs := func(t *testing.T, mt *mockTransport) {
+		mt.respBody = `
+				{
+					"data": {
+						"repositoryOwner": {
+							"sponsors": {
+								"edges": [
+									{
+										"node": {
+											"__typename": "User",
+											"login": "foo",
+											"name": "Foo",
+											"sponsorshipsAsSponsor":{"nodes":[{
+												"createdAt": "2024-03-01T10:00:00Z",
+												"privacyLevel": "PUBLIC",
+												"tier": {
+													"name": "$5 a month",
+													"monthlyPriceInDollars": 5,
+													"monthlyPriceInCents": 500
+												}
+											}]}
+										}
+									},
+									{
+										"node": {
+											"__typename": "User",
+											"login": "bar",
+											"name": "Bar",
+											"sponsorshipsAsSponsor":{"nodes":[{
+												"tier": null
+											}]}
+										}
+									},
+									{
+										"node": {
+											"__typename": "User",
+											"login": "baz",
+											"name": "Baz",
+											"sponsorshipsAsSponsor":{"nodes":[]}
+										}
+									},
+									{
+										"node": {
+											"__typename": "User",
+											"login": "qux",
+											"name": "Qux",
+											"sponsorshipsAsSponsor":{"nodes":[{
+												"isOneTimePayment": true,
+												"createdAt": "2023-05-01T10:00:00Z",
+												"privacyLevel": "PRIVATE",
+												"tier": {
+													"name": "$10 one time",
+													"monthlyPriceInDollars": 10,
+													"monthlyPriceInCents": 1000
+												}
+											}]}
+										}
+									}
+								],
+								"totalCount": 4
+							}
+						}
+					}
+				}`
+	}
+
+	pagedHTTPStubs := func(t *testing.T, mt *mockTransport) {
+		mt.respBodies = []string{
+			`{"data":{"repositoryOwner":{"sponsors":{"edges":[{"node":{"__typename":"User","login":"foo"}},{"node":{"__typename":"User","login":"bar"}}],"pageInfo":{"endCursor":"c1","hasNextPage":true}}}}}`,
+			`{"data":{"repositoryOwner":{"sponsors":{"edges":[{"node":{"__typename":"User","login":"baz"}}],"pageInfo":{"endCursor":"c2","hasNextPage":false}}}}}`,
+		}
+	}
+
+	mixedHTTPStubs := func(t *testing.T, mt *mockTransport) {
+		mt.respBody = `{"data":{"repositoryOwner":{"sponsors":{"edges":[
+			{"node":{"__typename":"Organization","login":"acme","name":"Acme"}},
+			{"node":{"__typename":"User","login":"foo","name":"Foo"}},
+			{"node":{"__typename":"Organization","login":"initech","name":"Initech"}}
+		]}}}}`
+	}
+
+	emptyRespHTTPStubs := func(t *testing.T, mt *mockTransport) {
+		mt.respBody = `
+				{
+					"data": {
+						"repositoryOwner": {
+							"sponsors": {
+								"edges": []
+							}
+						}
+					}
+				}`
+	}
+
+	tests := []struct {
+		name          string
+		tty           bool
+		opts          *ListOptions
+		httpStubs     func(*testing.T, *mockTransport)
+		prompterStubs func(*testing.T, *prompter.PrompterMock)
+		wantStdout    []string
+		wantStderr    string
+		wantErr       string
+	}{
+		{
+			name: "normal tty",
+			tty:  true,
+			opts: &ListOptions{
+				Username: "johndoe",
+			},
+			httpStubs: defaultHTTPStubs,
+			wantStdout: []string{
+				"SPONSOR  NAME",
+				"foo      Foo",
+				"bar      Bar",
+			},
+			wantStderr: "Showing 2 of 142 sponsors\n",
+		}, {
+			name: "no header tty",
+			tty:  true,
+			opts: &ListOptions{
+				Username: "johndoe",
+				NoHeader: true,
+			},
+			httpStubs: defaultHTTPStubs,
+			wantStdout: []string{
+				"foo  Foo",
+				"bar  Bar",
+			},
+			wantStderr: "Showing 2 of 142 sponsors\n",
+		}, {
+			name: "fields tty",
+			tty:  true,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Columns:  []string{"type", "login"},
+			},
+			httpStubs: defaultHTTPStubs,
+			wantStdout: []string{
+				"TYPE  SPONSOR",
+				"User  foo",
+				"User  bar",
+			},
+			wantStderr: "Showing 2 of 142 sponsors\n",
+		}, {
+			name: "fields no-tty",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Columns:  []string{"login", "tier", "amount"},
+			},
+			httpStubs: tierHTTPStubs,
+			wantStdout: []string{
+				"foo\t$5 a month\t$5",
+				"bar\t\t$0",
+				"baz\t\t$0",
+				"qux\t$10 one time\t$0",
+			},
+		}, {
+			name: "recurring no-tty",
+			tty:  false,
+			opts: &ListOptions{
+				Username:  "johndoe",
+				Recurring: true,
+			},
+			httpStubs: tierHTTPStubs,
+			wantStdout: []string{
+				"foo",
+				"bar",
+				"baz",
+			},
+		}, {
+			name: "one-time no-tty",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				OneTime:  true,
+			},
+			httpStubs: tierHTTPStubs,
+			wantStdout: []string{
+				"qux",
+			},
+		}, {
+			name: "since no-tty",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Since:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			httpStubs: tierHTTPStubs,
+			wantStdout: []string{
+				"foo",
+			},
+		}, {
+			name: "since and until inclusive no-tty",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Since:    time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC),
+				Until:    time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			},
+			httpStubs: tierHTTPStubs,
+			wantStdout: []string{
+				"foo",
+				"qux",
+			},
+		}, {
+			name: "until no-tty",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Until:    time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+			},
+			httpStubs: tierHTTPStubs,
+			wantStdout: []string{
+				"qux",
+			},
+		}, {
+			name: "public no-tty",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Public:   true,
+			},
+			httpStubs: tierHTTPStubs,
+			wantStdout: []string{
+				"foo",
+			},
+		}, {
+			name: "private json",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Private:  true,
+				Fields:   []string{"login", "privacy", "isOneTime"},
+			},
+			httpStubs:  tierHTTPStubs,
+			wantStdout: []string{`[{"isOneTime":true,"login":"qux","privacy":"private"}]`},
+		}, {
+			name: "profile json",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Fields:   []string{"login", "bio", "company", "location"},
+			},
+			httpStubs: func(_ *testing.T, mt *mockTransport) {
+				mt.respBody = `{"data":{"repositoryOwner":{"sponsors":{"edges":[
+					{"node":{"__typename":"User","login":"foo","name":"Foo","bio":"Hacker","company":"@acme","location":"Berlin"}},
+					{"node":{"__typename":"Organization","login":"acme","name":"Acme","location":"Paris"}}
+				]}}}}`
+			},
+			wantStdout: []string{`[{"bio":"Hacker","company":"@acme","location":"Berlin","login":"foo"},{"bio":"","company":"","location":"Paris","login":"acme"}]`},
+		}, {
+			name: "url and databaseId json",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Fields:   []string{"login", "url", "databaseId"},
+			},
+			httpStubs: func(_ *testing.T, mt *mockTransport) {
+				mt.respBody = `{"data":{"repositoryOwner":{"sponsors":{"edges":[
+					{"node":{"__typename":"User","login":"foo","url":"https://github.com/foo","databaseId":1234567}},
+					{"node":{"__typename":"Organization","login":"acme","url":"https://github.com/acme","databaseId":42}}
+				]}}}}`
+			},
+			wantStdout: []string{`[{"databaseId":1234567,"login":"foo","url":"https://github.com/foo"},{"databaseId":42,"login":"acme","url":"https://github.com/acme"}]`},
+		}, {
+			name: "website and twitter json",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Fields:   []string{"login", "websiteUrl", "twitterUsername"},
+			},
+			httpStubs: func(_ *testing.T, mt *mockTransport) {
+				mt.respBody = `{"data":{"repositoryOwner":{"sponsors":{"edges":[
+					{"node":{"__typename":"User","login":"foo","websiteUrl":"https://foo.dev","twitterUsername":"foodev"}},
+					{"node":{"__typename":"User","login":"bar","websiteUrl":null,"twitterUsername":null}},
+					{"node":{"__typename":"Organization","login":"acme","websiteUrl":"https://acme.com"}}
+				]}}}}`
+			},
+			wantStdout: []string{`[{"login":"foo","twitterUsername":"foodev","websiteUrl":"https://foo.dev"},{"login":"bar","twitterUsername":"","websiteUrl":""},{"login":"acme","twitterUsername":"","websiteUrl":"https://acme.com"}]`},
+		}, {
+			name: "socials json",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Fields:   []string{"login", "socials"},
+			},
+			httpStubs:  socialsHTTPStubs,
+			wantStdout: []string{`[{"login":"foo","socials":[{"provider":"mastodon","displayName":"@foo@mastodon.social"},{"provider":"generic","displayName":"foo.dev"}]},{"login":"acme","socials":[]}]`},
+		}, {
+			name: "socials tty",
+			tty:  true,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Columns:  []string{"login", "socials"},
+			},
+			httpStubs: socialsHTTPStubs,
+			wantStdout: []string{
+				"SPONSOR  SOCIALS",
+				"foo      @foo@mastodon.social (mastodon), foo.dev (generic)",
+				"acme     ",
+			},
+			wantStderr: "Showing 2 of 0 sponsors\n",
+		}, {
+			name: "normal tty, empty name",
+			tty:  true,
+			opts: &ListOptions{
+				Username: "johndoe",
+			},
+			httpStubs: func(_ *testing.T, mt *mockTransport) {
+				mt.respBody = `{"data":{"repositoryOwner":{"sponsors":{"edges":[
+					{"node":{"__typename":"User","login":"foo","name":"Foo"}},
+					{"node":{"__typename":"User","login":"bar","name":""}}
+				],"totalCount":2}}}}`
+			},
+			wantStdout: []string{
+				"SPONSOR  NAME",
+				"foo      Foo",
+				"bar      -",
+			},
+			wantStderr: "Showing 2 of 2 sponsors\n",
+		}, {
+			name: "normal tty, null name",
+			tty:  true,
+			opts: &ListOptions{
+				Username: "johndoe",
+			},
+			httpStubs: func(_ *testing.T, mt *mockTransport) {
+				mt.respBody = `{"data":{"repositoryOwner":{"sponsors":{"edges":[
+					{"node":{"__typename":"User","login":"foo","name":"Foo"}},
+					{"node":{"__typename":"User","login":"bar","name":null}}
+				],"totalCount":2}}}}`
+			},
+			wantStdout: []string{
+				"SPONSOR  NAME",
+				"foo      Foo",
+				"bar      -",
+			},
+			wantStderr: "Showing 2 of 2 sponsors\n",
+		}, {
+			name: "normal no-tty, null name",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+			},
+			httpStubs: func(_ *testing.T, mt *mockTransport) {
+				mt.respBody = `{"data":{"repositoryOwner":{"sponsors":{"edges":[
+					{"node":{"__typename":"User","login":"bar","name":null}}
+				],"totalCount":1}}}}`
+			},
+			wantStdout: []string{
+				"bar",
+			},
+		}, {
+			name: "normal json, null name",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Fields:   []string{"login", "name"},
+			},
+			httpStubs: func(_ *testing.T, mt *mockTransport) {
+				mt.respBody = `{"data":{"repositoryOwner":{"sponsors":{"edges":[
+					{"node":{"__typename":"User","login":"bar","name":null}}
+				],"totalCount":1}}}}`
+			},
+			wantStdout: []string{`[{"login":"bar","name":""}]`},
+		}, {
+			name:      "normal tty, no-username",
+			tty:       true,
+			opts:      &ListOptions{},
+			httpStubs: defaultHTTPStubs,
+			prompterStubs: func(t *testing.T, pm *prompter.PrompterMock) {
+				pm.RegisterInput("Which user do you want to target?", func(_, def string) (string, error) {
+					assert.Empty(t, def)
+					return "johndoe", nil
+				})
+			},
+			wantStdout: []string{
+				"SPONSOR  NAME",
+				"foo      Foo",
+				"bar      Bar",
+			},
+			wantStderr: "Showing 2 of 142 sponsors\n",
+		}, {
+			name: "normal no-tty",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+			},
+			httpStubs: defaultHTTPStubs,
+			wantStdout: []string{
+				"foo",
+				"bar",
+			},
+		}, {
+			name: "tier no-tty",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+			},
+			httpStubs: tierHTTPStubs,
+			wantStdout: []string{
+				"foo",
+				"bar",
+				"baz",
+				"qux",
+			},
+		}, {
+			name: "normal json",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Fields:   []string{"login"},
+			},
+			httpStubs:  defaultHTTPStubs,
+			wantStdout: []string{"[{\"login\":\"foo\"},{\"login\":\"bar\"}]"},
+		}, {
+			name: "ndjson tty",
+			tty:  true,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Fields:   []string{"login"},
+				NDJSON:   true,
+			},
+			httpStubs: defaultHTTPStubs,
+			wantStdout: []string{
+				`{"login":"foo"}`,
+				`{"login":"bar"}`,
+			},
+		}, {
+			name: "ndjson all fields",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				NDJSON:   true,
+			},
+			httpStubs: defaultHTTPStubs,
+			wantStdout: []string{
+				`{"amount":0,"avatarUrl":"https://avatars.githubusercontent.com/u/1","bio":"","company":"","createdAt":"","databaseId":0,"isOneTime":false,"location":"","login":"foo","monthlyPriceInCents":0,"name":"Foo","privacy":"","socials":[],"tier":"","twitterUsername":"","type":"User","url":"","websiteUrl":""}`,
+				`{"amount":0,"avatarUrl":"","bio":"","company":"","createdAt":"","databaseId":0,"isOneTime":false,"location":"","login":"bar","monthlyPriceInCents":0,"name":"Bar","privacy":"","socials":[],"tier":"","twitterUsername":"","type":"User","url":"","websiteUrl":""}`,
+			},
+		}, {
+			name: "json compact tty",
+			tty:  true,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Fields:   []string{"login"},
+				Compact:  true,
+			},
+			httpStubs:  defaultHTTPStubs,
+			wantStdout: []string{"[{\"login\":\"foo\"},{\"login\":\"bar\"}]"},
+		}, {
+			name: "json pretty no-tty",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Fields:   []string{"login"},
+				Pretty:   true,
+			},
+			httpStubs: defaultHTTPStubs,
+			wantStdout: []string{
+				"[",
+				"  {",
+				"    \"login\": \"foo\"",
+				"  },",
+				"  {",
+				"    \"login\": \"bar\"",
+				"  }",
+				"]",
+			},
+		}, {
+			name: "normal json all fields",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Fields:   listFields,
+			},
+			httpStubs:  defaultHTTPStubs,
+			wantStdout: []string{"[{\"amount\":0,\"avatarUrl\":\"https://avatars.githubusercontent.com/u/1\",\"bio\":\"\",\"company\":\"\",\"createdAt\":\"\",\"databaseId\":0,\"isOneTime\":false,\"location\":\"\",\"login\":\"foo\",\"monthlyPriceInCents\":0,\"name\":\"Foo\",\"privacy\":\"\",\"socials\":[],\"tier\":\"\",\"twitterUsername\":\"\",\"type\":\"User\",\"url\":\"\",\"websiteUrl\":\"\"},{\"amount\":0,\"avatarUrl\":\"\",\"bio\":\"\",\"company\":\"\",\"createdAt\":\"\",\"databaseId\":0,\"isOneTime\":false,\"location\":\"\",\"login\":\"bar\",\"monthlyPriceInCents\":0,\"name\":\"Bar\",\"privacy\":\"\",\"socials\":[],\"tier\":\"\",\"twitterUsername\":\"\",\"type\":\"User\",\"url\":\"\",\"websiteUrl\":\"\"}]"},
+		}, {
+			name: "all no-tty",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				All:      true,
+			},
+			httpStubs: pagedHTTPStubs,
+			wantStdout: []string{
+				"foo",
+				"bar",
+				"baz",
+			},
+		}, {
+			name: "all no-tty, limit as hard cap",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				All:      true,
+				Limit:    2,
+			},
+			httpStubs: pagedHTTPStubs,
+			wantStdout: []string{
+				"foo",
+				"bar",
+			},
+		}, {
+			name: "type json",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Fields:   []string{"login", "type"},
+			},
+			httpStubs:  mixedHTTPStubs,
+			wantStdout: []string{"[{\"login\":\"acme\",\"type\":\"Organization\"},{\"login\":\"foo\",\"type\":\"User\"},{\"login\":\"initech\",\"type\":\"Organization\"}]"},
+		}, {
+			name: "org only no-tty",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				OrgOnly:  true,
+			},
+			httpStubs: mixedHTTPStubs,
+			wantStdout: []string{
+				"acme",
+				"initech",
+			},
+		}, {
+			name: "user only no-tty",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				UserOnly: true,
+			},
+			httpStubs: mixedHTTPStubs,
+			wantStdout: []string{
+				"foo",
+			},
+		}, {
+			name: "csv tty",
+			tty:  true,
+			opts: &ListOptions{
+				Username: "johndoe",
+				CSV:      true,
+			},
+			httpStubs: func(_ *testing.T, mt *mockTransport) {
+				mt.respBody = `{"data":{"repositoryOwner":{"sponsors":{"edges":[
+					{"node":{"__typename":"User","login":"foo","name":"Foo, Jr."}},
+					{"node":{"__typename":"User","login":"bar","name":"The \"Bar\""}},
+					{"node":{"__typename":"User","login":"baz","name":""}}
+				]}}}}`
+			},
+			wantStdout: []string{
+				"login,name",
+				"foo,\"Foo, Jr.\"",
+				"bar,\"The \"\"Bar\"\"\"",
+				"baz,",
+			},
+		}, {
+			name: "csv no-tty, selected fields",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				CSV:      true,
+				Columns:  []string{"login", "tier", "amount", "createdAt"},
+			},
+			httpStubs: tierHTTPStubs,
+			wantStdout: []string{
+				"login,tier,amount,createdAt",
+				"foo,$5 a month,5,2024-03-01T10:00:00Z",
+				"bar,,0,",
+				"baz,,0,",
+				"qux,$10 one time,0,2023-05-01T10:00:00Z",
+			},
+		}, {
+			name: "csv no-tty, no sponsor",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				CSV:      true,
+			},
+			httpStubs:  emptyRespHTTPStubs,
+			wantStdout: []string{"login,name"},
+		}, {
+			name: "csv no-tty, no header",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				CSV:      true,
+				NoHeader: true,
+			},
+			httpStubs: defaultHTTPStubs,
+			wantStdout: []string{
+				"foo,Foo",
+				"bar,Bar",
+			},
+		}, {
+			name: "tsv tty",
+			tty:  true,
+			opts: &ListOptions{
+				Username: "johndoe",
+				TSV:      true,
+			},
+			httpStubs: func(_ *testing.T, mt *mockTransport) {
+				mt.respBody = `{"data":{"repositoryOwner":{"sponsors":{"edges":[
+					{"node":{"__typename":"User","login":"foo","name":"Foo, Jr."}},
+					{"node":{"__typename":"User","login":"bar","name":"The \"Bar\""}},
+					{"node":{"__typename":"User","login":"baz","name":""}}
+				]}}}}`
+			},
+			wantStdout: []string{
+				"login\tname",
+				"foo\tFoo, Jr.",
+				"bar\tThe \"Bar\"",
+				"baz\t",
+			},
+		}, {
+			name: "tsv no-tty, tabs and newlines",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				TSV:      true,
+				Columns:  []string{"login", "bio"},
+			},
+			httpStubs: func(_ *testing.T, mt *mockTransport) {
+				mt.respBody = `{"data":{"repositoryOwner":{"sponsors":{"edges":[
+					{"node":{"__typename":"User","login":"foo","bio":"line one\nline two"}},
+					{"node":{"__typename":"User","login":"bar","bio":"tab\there\r\nthere"}}
+				]}}}}`
+			},
+			wantStdout: []string{
+				"login\tbio",
+				"foo\tline one line two",
+				"bar\ttab here there",
+			},
+		}, {
+			name: "tsv no-tty, no header",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				TSV:      true,
+				Columns:  []string{"login", "name"},
+				NoHeader: true,
+			},
+			httpStubs: defaultHTTPStubs,
+			wantStdout: []string{
+				"foo\tFoo",
+				"bar\tBar",
+			},
+		}, {
+			name: "template",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Template: `{{.Login}} ({{.Name}}, {{.Type}})`,
+			},
+			httpStubs: defaultHTTPStubs,
+			wantStdout: []string{
+				"foo (Foo, User)",
+				"bar (Bar, User)",
+			},
+		}, {
+			name: "template with functions",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Template: `{{printf "%-5s" .Login}}{{.AmountInDollars}}`,
+			},
+			httpStubs: defaultHTTPStubs,
+			wantStdout: []string{
+				"foo  0",
+				"bar  0",
+			},
+		}, {
+			name: "jq without json",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				JQ:       `.[] | "\(.login) \(.type)"`,
+			},
+			httpStubs: defaultHTTPStubs,
+			wantStdout: []string{
+				"foo User",
+				"bar User",
+			},
+		}, {
+			name: "jq with json",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Fields:   []string{"login"},
+				JQ:       `map(keys)`,
+			},
+			httpStubs:  defaultHTTPStubs,
+			wantStdout: []string{`[["login"],["login"]]`},
+		}, {
+			name: "jq pretty",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Fields:   []string{"login"},
+				JQ:       `map(.login)`,
+				Pretty:   true,
+			},
+			httpStubs: defaultHTTPStubs,
+			wantStdout: []string{
+				"[",
+				`  "foo",`,
+				`  "bar"`,
+				"]",
+			},
+		}, {
+			name: "jq compact tty",
+			tty:  true,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Fields:   []string{"login"},
+				JQ:       `map(.login)`,
+				Compact:  true,
+			},
+			httpStubs:  defaultHTTPStubs,
+			wantStdout: []string{`["foo","bar"]`},
+		}, {
+			name: "jq with json, streaming logins",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Fields:   []string{"login"},
+				JQ:       `.[].login`,
+			},
+			httpStubs: defaultHTTPStubs,
+			wantStdout: []string{
+				"foo",
+				"bar",
+			},
+		}, {
+			name: "failure invalid jq",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				JQ:       `.[`,
+			},
+			httpStubs: defaultHTTPStubs,
+			wantErr:   "failed to parse jq expression (line 1, column 3)\n    .[\n      ^  unexpected EOF",
+		}, {
+			name: "failure malformed template",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Template: `{{if .Login}}`,
+			},
+			httpStubs: defaultHTTPStubs,
+			wantErr:   "failed to parse template: template: :1: unexpected EOF",
+		}, {
+			name: "failure template unknown field",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Template: `{{.login}}`,
+			},
+			httpStubs: defaultHTTPStubs,
+			wantErr:   `failed to execute template: template: :1:2: executing "" at <.login>: can't evaluate field login in type main.sponsor`,
+		}, {
+			name: "tier tty",
+			tty:  true,
+			opts: &ListOptions{
+				Username: "johndoe",
+			},
+			httpStubs: tierHTTPStubs,
+			wantStdout: []string{
+				"SPONSOR  NAME  TIER          MONTHLY  SINCE",
+				"foo      Foo   $5 a month    $5       2024-03-01",
+				"bar      Bar                          ",
+				"baz      Baz                          ",
+				"qux      Qux   $10 one time           2023-05-01",
+			},
+			wantStderr: "Showing 4 of 4 sponsors\n",
+		}, {
+			name: "filter tty",
+			tty:  true,
+			opts: &ListOptions{
+				Username: "johndoe",
+				OneTime:  true,
+			},
+			httpStubs: tierHTTPStubs,
+			wantStdout: []string{
+				"SPONSOR  NAME  TIER          SINCE",
+				"qux      Qux   $10 one time  2023-05-01",
+			},
+			wantStderr: "Showing 1 matching sponsors\n",
+		}, {
+			name: "tier json",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Fields:   []string{"login", "tier"},
+			},
+			httpStubs:  tierHTTPStubs,
+			wantStdout: []string{"[{\"login\":\"foo\",\"tier\":\"$5 a month\"},{\"login\":\"bar\",\"tier\":\"\"},{\"login\":\"baz\",\"tier\":\"\"},{\"login\":\"qux\",\"tier\":\"$10 one time\"}]"},
+		}, {
+			name: "amount json",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Fields:   []string{"login", "amount"},
+			},
+			httpStubs:  tierHTTPStubs,
+			wantStdout: []string{"[{\"amount\":5,\"login\":\"foo\"},{\"amount\":0,\"login\":\"bar\"},{\"amount\":0,\"login\":\"baz\"},{\"amount\":0,\"login\":\"qux\"}]"},
+		}, {
+			name: "createdAt json",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Fields:   []string{"login", "createdAt"},
+			},
+			httpStubs:  tierHTTPStubs,
+			wantStdout: []string{"[{\"createdAt\":\"2024-03-01T10:00:00Z\",\"login\":\"foo\"},{\"createdAt\":\"\",\"login\":\"bar\"},{\"createdAt\":\"\",\"login\":\"baz\"},{\"createdAt\":\"2023-05-01T10:00:00Z\",\"login\":\"qux\"}]"},
+		}, {
+			name: "sort by name ascending",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Fields:   []string{"login"},
+				Sort:     "name",
+				Order:    "asc",
+			},
+			httpStubs: func(_ *testing.T, mt *mockTransport) {
+				mt.respBody = `{"data":{"repositoryOwner":{"sponsors":{"edges":[
+					{"node":{"__typename":"User","login":"aaa","name":"Zed"}},
+					{"node":{"__typename":"User","login":"bob","name":""}},
+					{"node":{"__typename":"User","login":"ccc","name":"alice"}}
+				]}}}}`
+			},
+			wantStdout: []string{"[{\"login\":\"ccc\"},{\"login\":\"bob\"},{\"login\":\"aaa\"}]"},
+		}, {
+			name: "sort by name descending",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Fields:   []string{"login"},
+				Sort:     "name",
+				Order:    "desc",
+			},
+			httpStubs:  defaultHTTPStubs,
+			wantStdout: []string{"[{\"login\":\"foo\"},{\"login\":\"bar\"}]"},
+		}, {
+			name: "sort by created ascending",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Fields:   []string{"login"},
+				Sort:     "created",
+				Order:    "asc",
+			},
+			httpStubs:  tierHTTPStubs,
+			wantStdout: []string{"[{\"login\":\"bar\"},{\"login\":\"baz\"},{\"login\":\"qux\"},{\"login\":\"foo\"}]"},
+		}, {
+			name: "sort by created descending",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Fields:   []string{"login"},
+				Sort:     "created",
+				Order:    "desc",
+			},
+			httpStubs:  tierHTTPStubs,
+			wantStdout: []string{"[{\"login\":\"foo\"},{\"login\":\"qux\"},{\"login\":\"bar\"},{\"login\":\"baz\"}]"},
+		}, {
+			name: "sort by created reversed",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Fields:   []string{"login"},
+				Sort:     "created",
+				Reverse:  true,
+			},
+			httpStubs:  tierHTTPStubs,
+			wantStdout: []string{"[{\"login\":\"foo\"},{\"login\":\"qux\"},{\"login\":\"bar\"},{\"login\":\"baz\"}]"},
+		}, {
+			name: "count tty",
+			tty:  true,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Count:    true,
+			},
+			httpStubs: func(_ *testing.T, mt *mockTransport) {
+				mt.respBody = `{"data":{"repositoryOwner":{"sponsors":{"totalCount":42}}}}`
+			},
+			wantStdout: []string{"42 sponsors"},
+		}, {
+			name: "count tty, single sponsor",
+			tty:  true,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Count:    true,
+			},
+			httpStubs: func(_ *testing.T, mt *mockTransport) {
+				mt.respBody = `{"data":{"repositoryOwner":{"sponsors":{"totalCount":1}}}}`
+			},
+			wantStdout: []string{"1 sponsor"},
+		}, {
+			name: "count no-tty",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Count:    true,
+			},
+			httpStubs: func(_ *testing.T, mt *mockTransport) {
+				mt.respBody = `{"data":{"repositoryOwner":{"sponsors":{"totalCount":42}}}}`
+			},
+			wantStdout: []string{"42"},
+		}, {
+			name: "count with filter",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Count:    true,
+				OneTime:  true,
+			},
+			httpStubs:  tierHTTPStubs,
+			wantStdout: []string{"1"},
+		}, {
+			name: "count with filter tty",
+			tty:  true,
+			opts: &ListOptions{
+				Username:  "johndoe",
+				Count:     true,
+				Recurring: true,
+			},
+			httpStubs:  tierHTTPStubs,
+			wantStdout: []string{"3 sponsors"},
+		}, {
+			name: "me no-tty, no-username",
+			tty:  false,
+			opts: &ListOptions{
+				Me: true,
+			},
+			httpStubs: func(t *testing.T, mt *mockTransport) {
+				mt.respBodies = []string{`{"data":{"viewer":{"login":"monalisa"}}}`}
+				defaultHTTPStubs(t, mt)
+			},
+			wantStdout: []string{
+				"foo",
+				"bar",
+			},
+		}, {
+			name: "me tty",
+			tty:  true,
+			opts: &ListOptions{
+				Me: true,
+			},
+			httpStubs: func(t *testing.T, mt *mockTransport) {
+				mt.respBodies = []string{`{"data":{"viewer":{"login":"monalisa"}}}`}
+				defaultHTTPStubs(t, mt)
+			},
+			wantStdout: []string{
+				"SPONSOR  NAME",
+				"foo      Foo",
+				"bar      Bar",
+			},
+			wantStderr: "Showing 2 of 142 sponsors\n",
+		}, {
+			name: "failure me no-tty, viewer error",
+			tty:  false,
+			opts: &ListOptions{
+				Me: true,
+			},
+			httpStubs: func(_ *testing.T, mt *mockTransport) {
+				mt.respBody = `{"data":{}, "errors": [{"message": "some gql error"}]}`
+			},
+			wantErr: "GraphQL: some gql error",
+		}, {
+			name: "total",
+			tty:  true,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Total:    true,
+			},
+			httpStubs: tierHTTPStubs,
+			wantStdout: []string{
+				"Estimated monthly total: $5",
+				"One-time total: $10",
+			},
+		}, {
+			name: "total fetches all pages",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Total:    true,
+			},
+			httpStubs: func(_ *testing.T, mt *mockTransport) {
+				node := func(login string, amount int) string {
+					return userEdge(login, fmt.Sprintf(`"sponsorshipsAsSponsor":{"nodes":[{"tier":{"monthlyPriceInDollars":%d}}]}`, amount))
+				}
+				mt.respBodies = []string{
+					sponsorsPage(3, "c1", true, node("foo", 1000), node("bar", 200)),
+					sponsorsPage(3, "c2", false, node("baz", 50)),
+				}
+			},
+			wantStdout: []string{
+				"Estimated monthly total: $1,250",
+				"One-time total: $0",
+			},
+		}, {
+			name: "failure tty, prompt error",
+			tty:  true,
+			opts: &ListOptions{},
+			prompterStubs: func(t *testing.T, pm *prompter.PrompterMock) {
+				pm.RegisterInput("Which user do you want to target?", func(_, def string) (string, error) {
+					assert.Empty(t, def)
+					return "", errors.New("prompt error")
+				})
+			},
+			wantErr: "prompt error",
+		}, {
+			name:    "failure no-tty, no-username",
+			tty:     false,
+			opts:    &ListOptions{},
+			wantErr: "username not provided",
+		}, {
+			name: "normal tty, no sponsor",
+			tty:  true,
+			opts: &ListOptions{
+				Username: "johndoe",
+			},
+			httpStubs:  emptyRespHTTPStubs,
+			wantStderr: "no sponsor found\n",
+		}, {
+			name: "normal tty, no sponsor, quiet",
+			tty:  true,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Quiet:    true,
+			},
+			httpStubs: emptyRespHTTPStubs,
+		}, {
+			name: "normal tty, quiet",
+			tty:  true,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Quiet:    true,
+			},
+			httpStubs: defaultHTTPStubs,
+			wantStdout: []string{
+				"SPONSOR  NAME",
+				"foo      Foo",
+				"bar      Bar",
+			},
+		}, {
+			name: "normal no-tty, no sponsor",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+			},
+			httpStubs: emptyRespHTTPStubs,
+		}, {
+			name: "json tty, no sponsor",
+			tty:  true,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Fields:   []string{"login"},
+				Compact:  true,
+			},
+			httpStubs:  emptyRespHTTPStubs,
+			wantStdout: []string{"[]"},
+		}, {
+			name: "json tty pretty, no sponsor",
+			tty:  true,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Fields:   []string{"login"},
+			},
+			httpStubs:  emptyRespHTTPStubs,
+			wantStdout: []string{"\x1b[1;38m[\x1b[m\x1b[1;38m]\x1b[m"},
+		}, {
+			name: "json no-tty, no sponsor",
+			tty:  false,
+			opts: &ListOptions{
+				Username: "johndoe",
+				Fields:   []string{"login"},
+			},
+			httpStubs:  emptyRespHTTPStubs,
+			wantStdout: []string{"[]"},
+		}, {
+			name: "api error",
+			tty:  true,
+			opts: &ListOptions{
+				Username: "johndoe",
+			},
+			httpStubs: func(_ *testing.T, mt *mockTransport) {
+				mt.respBody = `{"data":{}, "errors": [{"message": "some gql error"}]}`
+			},
+			wantErr: "failed to list sponsors for \"johndoe\": GraphQL: some gql error",
+		}, {
+			name: "failure user not found",
+			tty:  true,
+			opts: &ListOptions{
+				Username: "doesnotexist",
+			},
+			httpStubs: func(_ *testing.T, mt *mockTransport) {
+				mt.respBody = `{"data":{"repositoryOwner":null}}`
+			},
+			wantErr: "no such user: \"doesnotexist\"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTransport := &mockTransport{}
+			client, err := api.NewGraphQLClient(api.ClientOptions{
+				Host:      "foo",
+				AuthToken: "bar",
+				Transport: mockTransport,
+			})
+			require.NoError(t, err)
+
+			pm := &prompter.PrompterMock{}
+			if tt.prompterStubs != nil {
+				tt.prompterStubs(t, pm)
+			}
+			tt.opts.Prompter = pm
+
+			ios := &mockTerminal{
+				width:  999,
+				height: 999,
+			}
+			ios.isTTY = tt.tty
+
+			tt.opts.IOs = ios
+			tt.opts.Client = client
+
+			if tt.httpStubs != nil {
+				tt.httpStubs(t, mockTransport)
+			}
+
+			err = listRun(context.Background(), tt.opts)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			expectedStdout := ""
+			if len(tt.wantStdout) > 0 {
+				expectedStdout = fmt.Sprintf("%s\n", strings.Join(tt.wantStdout, "\n"))
+			}
+			assert.Equal(t, expectedStdout, ios.stdout.String())
+			assert.Equal(t, tt.wantStderr, ios.stderr.String())
+		})
+	}
+}
+
+func Test_formatCents(t *testing.T) {
+	tests := []struct {
+		cents int
+		want  string
+	}{
+		{cents: 0, want: ""},
+		{cents: 5, want: "$0.05"},
+		{cents: 100, want: "$1"},
+		{cents: 450, want: "$4.50"},
+		{cents: 2500, want: "$25"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, formatCents(tt.cents))
+		})
+	}
+}
+
+func Test_formatDollars(t *testing.T) {
+	tests := []struct {
+		dollars int
+		want    string
+	}{
+		{dollars: 0, want: "$0"},
+		{dollars: 25, want: "$25"},
+		{dollars: 999, want: "$999"},
+		{dollars: 1000, want: "$1,000"},
+		{dollars: 1250, want: "$1,250"},
+		{dollars: 1234567, want: "$1,234,567"},
+		{dollars: -1500, want: "-$1,500"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, formatDollars(tt.dollars))
+		})
+	}
+}
+
+// sponsorsPage returns the body of a response holding a page of sponsors
+// with the given edges, built with userEdge or orgEdge. Unless empty,
+// endCursor adds pageInfo to the page, with a next page if hasNextPage.
+func sponsorsPage(totalCount int, endCursor string, hasNextPage bool, edges ...string) string {
+	pageInfo := ""
+	if endCursor != "" {
+		pageInfo = fmt.Sprintf(`,"pageInfo":{"endCursor":%q,"hasNextPage":%t}`, endCursor, hasNextPage)
+	}
+	return fmt.Sprintf(`{"data":{"repositoryOwner":{"sponsors":{"edges":[%s]%s,"totalCount":%d}}}}`, strings.Join(edges, ","), pageInfo, totalCount)
+}
+
+// userEdge returns a sponsors edge of the user with the given login, its node
+// having the given raw JSON fields too, e.g. `"name":"Foo"`.
+func userEdge(login string, fields ...string) string {
+	return sponsorEdge("User", login, fields)
+}
+
+// orgEdge is like userEdge, for an organization.
+func orgEdge(login string, fields ...string) string {
+	return sponsorEdge("Organization", login, fields)
+}
+
+// userEdges returns a sponsors edge for each of the given user logins.
+func userEdges(logins ...string) []string {
+	edges := make([]string, 0, len(logins))
+	for _, login := range logins {
+		edges = append(edges, userEdge(login))
+	}
+	return edges
+}
+
+func sponsorEdge(typename, login string, fields []string) string {
+	node := append([]string{fmt.Sprintf(`"__typename":%q`, typename), fmt.Sprintf(`"login":%q`, login)}, fields...)
+	return fmt.Sprintf(`{"node":{%s}}`, strings.Join(node, ","))
+}
+
+type mockTransport struct {
+	respBody       string
+	respStatusCode int
+
+	// respBodies, when set, are returned in order for consecutive requests,
+	// taking precedence over respBody.
+	respBodies []string
+	reqBodies  []string
+
+	// respStatusCodes and respHeaders, when set, are returned in order for
+	// consecutive requests, taking precedence over respStatusCode.
+	respStatusCodes []int
+	respHeaders     []http.Header
+}
+
+func (t *mockTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if r.Body != nil {
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		t.reqBodies = append(t.reqBodies, string(b))
+	}
+
+	body := t.respBody
+	if len(t.respBodies) > 0 {
+		body = t.respBodies[0]
+		t.respBodies = t.respBodies[1:]
+	}
+
+	statusCode := t.respStatusCode
+	if len(t.respStatusCodes) > 0 {
+		statusCode = t.respStatusCodes[0]
+		t.respStatusCodes = t.respStatusCodes[1:]
+	}
+
+	rec := httptest.NewRecorder()
+	if len(t.respHeaders) > 0 {
+		for k, v := range t.respHeaders[0] {
+			rec.Header()[k] = v
+		}
+		t.respHeaders = t.respHeaders[1:]
+	}
+	if statusCode != 0 {
+		rec.WriteHeader(statusCode)
+	}
+	_, _ = rec.WriteString(body)
+	resp := rec.Result()
+	resp.Request = r
+	return resp, nil
+}
+
+func Test_listRun_orderBy(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        *ListOptions
+		wantOrderBy string
+	}{
+		{
+			name:        "default",
+			opts:        &ListOptions{},
+			wantOrderBy: `{"field":"LOGIN","direction":"ASC"}`,
+		}, {
+			name:        "reverse",
+			opts:        &ListOptions{Reverse: true},
+			wantOrderBy: `{"field":"LOGIN","direction":"DESC"}`,
+		}, {
+			name:        "reverse descending order",
+			opts:        &ListOptions{Order: "desc", Reverse: true},
+			wantOrderBy: `{"field":"LOGIN","direction":"ASC"}`,
+		}, {
+			name:        "reverse relevance",
+			opts:        &ListOptions{Sort: "relevance", Reverse: true},
+			wantOrderBy: `{"field":"RELEVANCE","direction":"DESC"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTransport := &mockTransport{
+				respBody: `{"data":{"repositoryOwner":{"sponsors":{"edges":[]}}}}`,
+			}
+			client, err := api.NewGraphQLClient(api.ClientOptions{
+				Host:      "foo",
+				AuthToken: "bar",
+				Transport: mockTransport,
+			})
+			require.NoError(t, err)
+
+			tt.opts.Username = "johndoe"
+			tt.opts.Client = client
+			tt.opts.IOs = &mockTerminal{}
+
+			require.NoError(t, listRun(context.Background(), tt.opts))
+
+			require.Len(t, mockTransport.reqBodies, 1)
+			var req struct {
+				Variables struct {
+					OrderBy json.RawMessage `json:"orderBy"`
+				} `json:"variables"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(mockTransport.reqBodies[0]), &req))
+			assert.JSONEq(t, tt.wantOrderBy, string(req.Variables.OrderBy))
+		})
+	}
+}
+
+func Test_listRun_limitEnv(t *testing.T) {
+	tests := []struct {
+		name       string
+		env        *string
+		opts       *ListOptions
+		wantFirst  int
+		wantStderr string
+	}{
+		{
+			name:      "unset",
+			opts:      &ListOptions{},
+			wantFirst: 30,
+		}, {
+			name:      "empty",
+			env:       ptr(""),
+			opts:      &ListOptions{},
+			wantFirst: 30,
+		}, {
+			name:      "valid",
+			env:       ptr("75"),
+			opts:      &ListOptions{},
+			wantFirst: 75,
+		}, {
+			name:      "overridden by --limit",
+			env:       ptr("75"),
+			opts:      &ListOptions{Limit: 10},
+			wantFirst: 10,
+		}, {
+			name:      "ignored with --all",
+			env:       ptr("75"),
+			opts:      &ListOptions{All: true},
+			wantFirst: 100,
+		}, {
+			name:       "not a number",
+			env:        ptr("lots"),
+			opts:       &ListOptions{},
+			wantFirst:  30,
+			wantStderr: "warning: ignoring invalid GH_SPONSORS_LIMIT value \"lots\", using 30\n",
+		}, {
+			name:       "zero",
+			env:        ptr("0"),
+			opts:       &ListOptions{},
+			wantFirst:  30,
+			wantStderr: "warning: ignoring invalid GH_SPONSORS_LIMIT value \"0\", using 30\n",
+		}, {
+			name:       "above max",
+			env:        ptr("101"),
+			opts:       &ListOptions{},
+			wantFirst:  30,
+			wantStderr: "warning: ignoring invalid GH_SPONSORS_LIMIT value \"101\", using 30\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.env != nil {
+				t.Setenv(listLimitEnv, *tt.env)
+			} else {
+				t.Setenv(listLimitEnv, "")
+				os.Unsetenv(listLimitEnv)
+			}
+
+			mockTransport := &mockTransport{
+				respBody: `{"data":{"repositoryOwner":{"sponsors":{"edges":[]}}}}`,
+			}
+			client, err := api.NewGraphQLClient(api.ClientOptions{
+				Host:      "foo",
+				AuthToken: "bar",
+				Transport: mockTransport,
+			})
+			require.NoError(t, err)
+
+			ios := &mockTerminal{}
+			tt.opts.Username = "johndoe"
+			tt.opts.Client = client
+			tt.opts.IOs = ios
+
+			require.NoError(t, listRun(context.Background(), tt.opts))
+
+			require.Len(t, mockTransport.reqBodies, 1)
+			var req struct {
+				Variables struct {
+					First int `json:"first"`
+				} `json:"variables"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(mockTransport.reqBodies[0]), &req))
+			assert.Equal(t, tt.wantFirst, req.Variables.First)
+			assert.Equal(t, tt.wantStderr, ios.stderr.String())
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func Test_listRun_me(t *testing.T) {
+	mockTransport := &mockTransport{
+		respBodies: []string{
+			`{"data":{"viewer":{"login":"monalisa"}}}`,
+			`{"data":{"repositoryOwner":{"sponsors":{"edges":[]}}}}`,
+		},
+	}
+	client, err := api.NewGraphQLClient(api.ClientOptions{
+		Host:      "foo",
+		AuthToken: "bar",
+		Transport: mockTransport,
+	})
+	require.NoError(t, err)
+
+	opts := &ListOptions{
+		Client:   client,
+		IOs:      &mockTerminal{isTTY: true},
+		Prompter: &prompter.PrompterMock{},
+		Me:       true,
+	}
+	require.NoError(t, listRun(context.Background(), opts))
+
+	require.Len(t, mockTransport.reqBodies, 2)
+	assert.Contains(t, mockTransport.reqBodies[0], "viewer{login}")
+	var req struct {
+		Variables struct {
+			Login string `json:"login"`
+		} `json:"variables"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(mockTransport.reqBodies[1]), &req))
+	assert.Equal(t, "monalisa", req.Variables.Login)
+	assert.Contains(t, mockTransport.reqBodies[1], "avatarUrl")
+}
+
+func Test_listRun_avatarSize(t *testing.T) {
+	tests := []struct {
+		name       string
+		avatarSize int
+		wantSize   *int
+	}{
+		{
+			name:       "sized",
+			avatarSize: 128,
+			wantSize:   ptr(128),
+		}, {
+			name:       "original size",
+			avatarSize: 0,
+			wantSize:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTransport := &mockTransport{
+				respBody: `{"data":{"repositoryOwner":{"sponsors":{"edges":[]}}}}`,
+			}
+			client, err := api.NewGraphQLClient(api.ClientOptions{
+				Host:      "foo",
+				AuthToken: "bar",
+				Transport: mockTransport,
+			})
+			require.NoError(t, err)
+
+			opts := &ListOptions{
+				Client:     client,
+				IOs:        &mockTerminal{},
+				Prompter:   &prompter.PrompterMock{},
+				Username:   "johndoe",
+				AvatarSize: tt.avatarSize,
+			}
+			require.NoError(t, listRun(context.Background(), opts))
+
+			require.Len(t, mockTransport.reqBodies, 1)
+			assert.Contains(t, mockTransport.reqBodies[0], "avatarUrl(size: $size)")
+			var req struct {
+				Variables struct {
+					Size *int `json:"size"`
+				} `json:"variables"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(mockTransport.reqBodies[0]), &req))
+			assert.Equal(t, tt.wantSize, req.Variables.Size)
+		})
+	}
+}
+
+func Test_listRun_promptDefault(t *testing.T) {
+	mockTransport := &mockTransport{
+		respBodies: []string{
+			`{"data":{"viewer":{"login":"monalisa"}}}`,
+			`{"data":{"repositoryOwner":{"sponsors":{"edges":[]}}}}`,
+		},
+	}
+	client, err := api.NewGraphQLClient(api.ClientOptions{
+		Host:      "foo",
+		AuthToken: "bar",
+		Transport: mockTransport,
+	})
+	require.NoError(t, err)
+
+	pm := &prompter.PrompterMock{}
+	pm.RegisterInput("Which user do you want to target?", func(_, def string) (string, error) {
+		assert.Equal(t, "monalisa", def)
+		return def, nil
+	})
+
+	opts := &ListOptions{
+		Client:   client,
+		IOs:      &mockTerminal{isTTY: true},
+		Prompter: pm,
+	}
+	require.NoError(t, listRun(context.Background(), opts))
+
+	require.Len(t, mockTransport.reqBodies, 2)
+	var req struct {
+		Variables struct {
+			Login string `json:"login"`
+		} `json:"variables"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(mockTransport.reqBodies[1]), &req))
+	assert.Equal(t, "monalisa", req.Variables.Login)
+}
+
+func Test_printSponsorsTemplate(t *testing.T) {
+	now := func() time.Time { return time.Date(2024, 3, 31, 10, 0, 0, 0, time.UTC) }
+
+	sponsors := []sponsor{
+		{Login: "foo", Name: "Foo Bar", CreatedAt: time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)},
+		{Login: "bazqux", Name: "Baz", CreatedAt: time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)},
+	}
+
+	tests := []struct {
+		name       string
+		tty        bool
+		tmpl       string
+		wantStdout string
+	}{
+		{
+			name:       "fields",
+			tmpl:       `{{.Login}} - {{.Name}}`,
+			wantStdout: "foo - Foo Bar\nbazqux - Baz\n",
+		}, {
+			name:       "timeago",
+			tmpl:       `{{.Login}} {{timeago .CreatedAt}}`,
+			wantStdout: "foo about 1 month ago\nbazqux about 1 year ago\n",
+		}, {
+			name:       "timefmt",
+			tmpl:       `{{timefmt "2006-01" .CreatedAt}}`,
+			wantStdout: "2024-03\n2023-03\n",
+		}, {
+			name:       "truncate",
+			tmpl:       `{{truncate 5 .Name}}`,
+			wantStdout: "Fo...\nBaz\n",
+		}, {
+			name:       "tablerow tty",
+			tty:        true,
+			tmpl:       `{{tablerow .Login .Name}}`,
+			wantStdout: "foo     Foo Bar\nbazqux  Baz\n",
+		}, {
+			name:       "tablerow no-tty",
+			tmpl:       `{{tablerow .Login .Name}}`,
+			wantStdout: "foo\tFoo Bar\nbazqux\tBaz\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ios := &mockTerminal{isTTY: tt.tty, width: 999, height: 999}
+			require.NoError(t, printSponsorsTemplate(ios, sponsors, tt.tmpl, now))
+			assert.Equal(t, tt.wantStdout, ios.stdout.String())
+		})
+	}
+}
+
+func Test_listRun_web(t *testing.T) {
+	tests := []struct {
+		name       string
+		tty        bool
+		host       string
+		username   string
+		wantErr    string
+		wantStdout string
+		wantStderr string
+		wantURLs   []string
+	}{
+		{
+			name:       "normal tty",
+			tty:        true,
+			username:   "johndoe",
+			wantStderr: "Opening https://github.com/sponsors/johndoe in your browser.\n",
+			wantURLs:   []string{"https://github.com/sponsors/johndoe"},
+		}, {
+			name:       "enterprise host",
+			tty:        true,
+			host:       "ghe.example.com",
+			username:   "johndoe",
+			wantStderr: "Opening https://ghe.example.com/sponsors/johndoe in your browser.\n",
+			wantURLs:   []string{"https://ghe.example.com/sponsors/johndoe"},
+		}, {
+			name:       "normal no-tty",
+			tty:        false,
+			username:   "johndoe",
+			wantStdout: "https://github.com/sponsors/johndoe\n",
+		}, {
+			name:    "failure no-tty, no-username",
+			tty:     false,
+			wantErr: "username not provided",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTransport := &mockTransport{}
+			client, err := api.NewGraphQLClient(api.ClientOptions{
+				Host:      "foo",
+				AuthToken: "bar",
+				Transport: mockTransport,
+			})
+			require.NoError(t, err)
+
+			ios := &mockTerminal{isTTY: tt.tty}
+			browser := &mockBrowser{}
+			opts := &ListOptions{
+				Client:   client,
+				IOs:      ios,
+				Prompter: &prompter.PrompterMock{},
+				Browser:  browser,
+				Host:     tt.host,
+				Username: tt.username,
+				Web:      true,
+			}
+
+			err = listRun(context.Background(), opts)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantURLs, browser.urls)
+			assert.Empty(t, mockTransport.reqBodies)
+			assert.Equal(t, tt.wantStdout, ios.stdout.String())
+			assert.Equal(t, tt.wantStderr, ios.stderr.String())
+		})
+	}
+}
+
+func Test_listRun_timeout(t *testing.T) {
+	client, err := api.NewGraphQLClient(api.ClientOptions{
+		Host:      "foo",
+		AuthToken: "bar",
+		Transport: blockingTransport{},
+	})
+	require.NoError(t, err)
+
+	opts := &ListOptions{
+		Client:   client,
+		IOs:      &mockTerminal{},
+		Prompter: &prompter.PrompterMock{},
+		Username: "johndoe",
+		Timeout:  10 * time.Millisecond,
+	}
+	require.EqualError(t, listRun(context.Background(), opts), "request timed out after 10ms")
+}
+
+func Test_parsePeriod(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    period
+		wantErr bool
+	}{
+		{in: "30d", want: period{days: 30}},
+		{in: "2w", want: period{days: 14}},
+		{in: "6mo", want: period{months: 6}},
+		{in: "0d", wantErr: true},
+		{in: "-1d", wantErr: true},
+		{in: "30", wantErr: true},
+		{in: "1y", wantErr: true},
+		{in: "d", wantErr: true},
+		{in: "99999999999999999999d", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parsePeriod(tt.in)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_listRun_within(t *testing.T) {
+
+	respBody := `{"data":{"repositoryOwner":{"sponsors":{"edges":[
+		{"node":{"__typename":"User","login":"foo","sponsorshipsAsSponsor":{"nodes":[{"createdAt":"2024-03-30T12:00:00Z"}]}}},
+		{"node":{"__typename":"User","login":"bar","sponsorshipsAsSponsor":{"nodes":[{"createdAt":"2024-03-01T12:00:00Z"}]}}},
+		{"node":{"__typename":"User","login":"baz","sponsorshipsAsSponsor":{"nodes":[{"createdAt":"2023-12-01T12:00:00Z"}]}}},
+		{"node":{"__typename":"User","login":"qux","sponsorshipsAsSponsor":{"nodes":[]}}}
+	],"totalCount":4}}}}`
+
+	tests := []struct {
+		name       string
+		within     period
+		wantStdout string
+	}{
+		{
+			name:       "days",
+			within:     period{days: 7},
+			wantStdout: "foo\n",
+		}, {
+			name:       "weeks",
+			within:     period{days: 35},
+			wantStdout: "foo\nbar\n",
+		}, {
+			name:       "months",
+			within:     period{months: 6},
+			wantStdout: "foo\nbar\nbaz\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := api.NewGraphQLClient(api.ClientOptions{
+				Host:      "foo",
+				AuthToken: "bar",
+				Transport: &mockTransport{respBody: respBody},
+			})
+			require.NoError(t, err)
+
+			ios := &mockTerminal{}
+			opts := &ListOptions{
+				Client:   client,
+				IOs:      ios,
+				Prompter: &prompter.PrompterMock{},
+				Username: "johndoe",
+				Columns:  []string{"login"},
+				Within:   tt.within,
+				Now:      func() time.Time { return time.Date(2024, 3, 31, 10, 0, 0, 0, time.UTC) },
+			}
+
+			require.NoError(t, listRun(context.Background(), opts))
+			assert.Equal(t, tt.wantStdout, ios.stdout.String())
+		})
+	}
+}
+
+func Test_listRun_exitStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		tty        bool
+		opts       *ListOptions
+		respBodies []string
+		wantStdout string
+		wantErr    error
+	}{
+		{
+			name:       "sponsors",
+			opts:       &ListOptions{Username: "johndoe", Columns: []string{"login"}},
+			respBodies: []string{sponsorsPage(1, "", false, userEdge("foo"))},
+			wantStdout: "foo\n",
+		}, {
+			name:       "no sponsors tty",
+			tty:        true,
+			opts:       &ListOptions{Username: "johndoe"},
+			respBodies: []string{sponsorsPage(0, "", false)},
+			wantErr:    errSilent,
+		}, {
+			name:       "no sponsors json",
+			opts:       &ListOptions{Username: "johndoe", Fields: []string{"login"}},
+			respBodies: []string{sponsorsPage(0, "", false)},
+			wantErr:    errSilent,
+		}, {
+			name:       "no sponsors count tty",
+			tty:        true,
+			opts:       &ListOptions{Username: "johndoe", Count: true},
+			respBodies: []string{`{"data":{"repositoryOwner":{"sponsors":{"totalCount":0}}}}`},
+			wantErr:    errSilent,
+		}, {
+			name:       "batch with sponsors",
+			opts:       &ListOptions{Usernames: []string{"alice", "bob"}, Fields: []string{"login"}},
+			respBodies: []string{sponsorsPage(0, "", false), sponsorsPage(1, "", false, userEdge("foo"))},
+			wantStdout: `{"alice":[],"bob":[{"login":"foo"}]}` + "\n",
+		}, {
+			name:       "batch without sponsors",
+			opts:       &ListOptions{Usernames: []string{"alice", "bob"}, Fields: []string{"login"}},
+			respBodies: []string{sponsorsPage(0, "", false), sponsorsPage(0, "", false)},
+			wantErr:    errSilent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := api.NewGraphQLClient(api.ClientOptions{
+				Host:      "foo",
+				AuthToken: "bar",
+				Transport: &mockTransport{respBodies: tt.respBodies},
+			})
+			require.NoError(t, err)
+
+			ios := &mockTerminal{isTTY: tt.tty, width: 999, height: 999}
+			tt.opts.Client = client
+			tt.opts.IOs = ios
+			tt.opts.Prompter = &prompter.PrompterMock{}
+			tt.opts.ExitStatus = true
+
+			err = listRun(context.Background(), tt.opts)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wantStdout, ios.stdout.String())
+			assert.Empty(t, ios.stderr.String())
+		})
+	}
+}
+
+func Test_listSponsors_pageCap(t *testing.T) {
+	origMaxSponsorPages := maxSponsorPages
+	maxSponsorPages = 2
+	t.Cleanup(func() { maxSponsorPages = origMaxSponsorPages })
+
+	mockTransport := &mockTransport{respBodies: []string{sponsorsPage(3, "c1", true, userEdge("foo")), sponsorsPage(3, "c2", true, userEdge("bar")), sponsorsPage(3, "c3", true, userEdge("baz"))}}
+	client, err := api.NewGraphQLClient(api.ClientOptions{
+		Host:      "foo",
+		AuthToken: "bar",
+		Transport: mockTransport,
+	})
+	require.NoError(t, err)
+
+	errOut := &bytes.Buffer{}
+	sponsors, total, err := listSponsors(context.Background(), client, "johndoe", 0, sponsorOrder("login", "asc"), 0, 0, errOut)
+	require.NoError(t, err)
+
+	assert.Len(t, sponsors, 2)
+	assert.Equal(t, 3, total)
+	assert.Len(t, mockTransport.reqBodies, 2)
+	assert.Equal(t, "warning: stopped after fetching 2 pages, 2 of 3 sponsors\n", errOut.String())
+}
+
+func Test_listSponsors_sponsorships(t *testing.T) {
+	mockTransport := &mockTransport{
+		respBody: `{"data":{"repositoryOwner":{"sponsors":{"edges":[
+			{"node":{"__typename":"User","login":"foo","sponsorshipsAsSponsor":{"nodes":[{"createdAt":"2024-03-01T00:00:00Z","privacyLevel":"PUBLIC","tier":{"name":"$5 a month","monthlyPriceInDollars":5,"monthlyPriceInCents":500}}]}}},
+			{"node":{"__typename":"User","login":"bar","sponsorshipsAsSponsor":{"nodes":[]}}}
+		],"totalCount":2}}}}`,
+	}
+	client, err := api.NewGraphQLClient(api.ClientOptions{
+		Host:      "foo",
+		AuthToken: "bar",
+		Transport: mockTransport,
+	})
+	require.NoError(t, err)
+
+	sponsors, _, err := listSponsors(context.Background(), client, "johndoe", 0, sponsorOrder("login", "asc"), 0, 0, io.Discard)
+	require.NoError(t, err)
+
+	// The sponsorship of the listed account is queried on each sponsor, not
+	// the one of the viewer.
+	require.Len(t, mockTransport.reqBodies, 1)
+	assert.Contains(t, mockTransport.reqBodies[0], "sponsorshipsAsSponsor(first: 1, maintainerLogins: [$login])")
+	assert.NotContains(t, mockTransport.reqBodies[0], "sponsorshipForViewer")
+
+	require.Len(t, sponsors, 2)
+	assert.Equal(t, "$5 a month", sponsors[0].Tier)
+	assert.Equal(t, 5, sponsors[0].AmountInDollars)
+	assert.Equal(t, "public", sponsors[0].Privacy)
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), sponsors[0].CreatedAt)
+	assert.Empty(t, sponsors[1].Tier)
+	assert.True(t, sponsors[1].CreatedAt.IsZero())
+}
+
+func Test_listSponsors_userNotFound(t *testing.T) {
+	client, err := api.NewGraphQLClient(api.ClientOptions{
+		Host:      "foo",
+		AuthToken: "bar",
+		Transport: &mockTransport{respBody: `{"data":{"repositoryOwner":null}}`},
+	})
+	require.NoError(t, err)
+
+	_, _, err = listSponsors(context.Background(), client, "doesnotexist", 0, sponsorOrder("login", "asc"), 0, 0, io.Discard)
+	require.ErrorIs(t, err, ErrUserNotFound)
+	assert.EqualError(t, err, "no such user: \"doesnotexist\"")
+	assert.Equal(t, exitUserNotFound, exitCode(err))
+}
+
+func Test_listRun_filterLimit(t *testing.T) {
+	mockTransport := &mockTransport{
+		respBodies: []string{
+			sponsorsPage(4, "c", true, userEdge("alice"), userEdge("bob")),
+			sponsorsPage(4, "c", false, orgEdge("acme"), orgEdge("zeta")),
+		},
+	}
+	client, err := api.NewGraphQLClient(api.ClientOptions{
+		Host:      "foo",
+		AuthToken: "bar",
+		Transport: mockTransport,
+	})
+	require.NoError(t, err)
+
+	ios := &mockTerminal{}
+	opts := &ListOptions{
+		Client:   client,
+		IOs:      ios,
+		Prompter: &prompter.PrompterMock{},
+		Username: "johndoe",
+		Columns:  []string{"login"},
+		Limit:    1,
+		OrgOnly:  true,
+	}
+	require.NoError(t, listRun(context.Background(), opts))
+
+	// All pages are fetched, and the limit applies to the filtered sponsors.
+	require.Len(t, mockTransport.reqBodies, 2)
+	assert.Contains(t, mockTransport.reqBodies[0], `"first":100`)
+	assert.Equal(t, "acme\n", ios.stdout.String())
+}
+
+func Test_listRun_sortLimit(t *testing.T) {
+	node := func(login, name, createdAt string) string {
+		return userEdge(login, fmt.Sprintf(`"name":%q`, name), fmt.Sprintf(`"sponsorshipsAsSponsor":{"nodes":[{"createdAt":%q}]}`, createdAt))
+	}
+	respBodies := []string{
+		sponsorsPage(4, "c", true, node("alice", "Zoe", "2023-01-01T00:00:00Z"), node("bob", "Yann", "2024-02-01T00:00:00Z")),
+		sponsorsPage(4, "c", false, node("carol", "Adam", "2024-03-01T00:00:00Z"), node("dave", "Bea", "2022-01-01T00:00:00Z")),
+	}
+
+	tests := []struct {
+		name       string
+		sort       string
+		order      string
+		wantStdout string
+	}{
+		{
+			name:       "name",
+			sort:       "name",
+			order:      "asc",
+			wantStdout: "carol\ndave\n",
+		}, {
+			name:       "newest",
+			sort:       "created",
+			order:      "desc",
+			wantStdout: "carol\nbob\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTransport := &mockTransport{respBodies: slices.Clone(respBodies)}
+			client, err := api.NewGraphQLClient(api.ClientOptions{
+				Host:      "foo",
+				AuthToken: "bar",
+				Transport: mockTransport,
+			})
+			require.NoError(t, err)
+
+			ios := &mockTerminal{}
+			opts := &ListOptions{
+				Client:   client,
+				IOs:      ios,
+				Prompter: &prompter.PrompterMock{},
+				Username: "johndoe",
+				Columns:  []string{"login"},
+				Limit:    2,
+				Sort:     tt.sort,
+				Order:    tt.order,
+			}
+			require.NoError(t, listRun(context.Background(), opts))
+
+			// All pages are fetched before sorting, then truncated to the limit.
+			require.Len(t, mockTransport.reqBodies, 2)
+			assert.Equal(t, tt.wantStdout, ios.stdout.String())
+		})
+	}
+}
+
+func Test_listRun_tierColor(t *testing.T) {
+	node := func(login, tier string, dollars int, oneTime bool) string {
+		return userEdge(login, fmt.Sprintf(`"sponsorshipsAsSponsor":{"nodes":[{"isOneTimePayment":%t,"tier":{"name":%q,"monthlyPriceInDollars":%d,"monthlyPriceInCents":%d}}]}`, oneTime, tier, dollars, dollars*100))
+	}
+	respBody := sponsorsPage(5, "", false,
+		node("foo", "$5 a month", 5, false),
+		node("bar", "$30 a month", 30, false),
+		node("baz", "$150 a month", 150, false),
+		node("qux", "$10 one time", 10, true),
+		userEdge("quux", `"sponsorshipsAsSponsor":{"nodes":[]}`),
+	)
+
+	tests := []struct {
+		name         string
+		colorEnabled bool
+		noColor      bool
+		wantStdout   string
+	}{
+		{
+			name:         "color",
+			colorEnabled: true,
+			wantStdout: "SPONSOR  TIER\n" +
+				"\x1b[1mfoo\x1b[0m      \x1b[32m$5 a month\x1b[0m\n" +
+				"\x1b[1mbar\x1b[0m      \x1b[33m$30 a month\x1b[0m\n" +
+				"\x1b[1mbaz\x1b[0m      \x1b[31m$150 a month\x1b[0m\n" +
+				"\x1b[1mqux\x1b[0m      \x1b[2m$10 one time\x1b[0m\n" +
+				"\x1b[1mquux\x1b[0m     \n",
+		}, {
+			name:         "no-color flag",
+			colorEnabled: true,
+			noColor:      true,
+			wantStdout: "SPONSOR  TIER\n" +
+				"foo      $5 a month\n" +
+				"bar      $30 a month\n" +
+				"baz      $150 a month\n" +
+				"qux      $10 one time\n" +
+				"quux     \n",
+		}, {
+			name: "color disabled",
+			wantStdout: "SPONSOR  TIER\n" +
+				"foo      $5 a month\n" +
+				"bar      $30 a month\n" +
+				"baz      $150 a month\n" +
+				"qux      $10 one time\n" +
+				"quux     \n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := api.NewGraphQLClient(api.ClientOptions{
+				Host:      "foo",
+				AuthToken: "bar",
+				Transport: &mockTransport{respBody: respBody},
+			})
+			require.NoError(t, err)
+
+			ios := &mockTerminal{isTTY: true, colorEnabled: tt.colorEnabled, width: 999, height: 999}
+			opts := &ListOptions{
+				Client:   client,
+				IOs:      ios,
+				Prompter: &prompter.PrompterMock{},
+				Username: "johndoe",
+				Columns:  []string{"login", "tier"},
+				NoColor:  tt.noColor,
+			}
+
+			require.NoError(t, listRun(context.Background(), opts))
+			assert.Equal(t, tt.wantStdout, ios.stdout.String())
+		})
+	}
+}
+
+func Test_listRun_hyperlinks(t *testing.T) {
+	respBody := `{"data":{"repositoryOwner":{"sponsors":{"edges":[
+		{"node":{"__typename":"User","login":"foo","name":"Foo","url":"https://github.com/foo"}},
+		{"node":{"__typename":"Organization","login":"acme","name":"Acme","url":"https://github.com/acme"}}
+	],"totalCount":2}}}}`
+
+	tests := []struct {
+		name         string
+		tty          bool
+		colorEnabled bool
+		fields       []string
+		wantStdout   string
+	}{
+		{
+			name:         "tty",
+			tty:          true,
+			colorEnabled: true,
+			wantStdout: "SPONSOR  NAME\n" +
+				"\x1b[1m\x1b]8;;https://github.com/foo\x1b\\foo\x1b]8;;\x1b\\\x1b[0m      \x1b[2mFoo\x1b[0m\n" +
+				"\x1b[1m\x1b]8;;https://github.com/acme\x1b\\acme\x1b]8;;\x1b\\\x1b[0m     \x1b[2mAcme\x1b[0m\n",
+		}, {
+			name: "tty without color",
+			tty:  true,
+			wantStdout: "SPONSOR  NAME\n" +
+				"foo      Foo\n" +
+				"acme     Acme\n",
+		}, {
+			name:         "no-tty, color forced",
+			colorEnabled: true,
+			wantStdout:   "foo\nacme\n",
+		}, {
+			name:         "json tty",
+			tty:          true,
+			colorEnabled: true,
+			fields:       []string{"login"},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			argv, err := shlex.Split(tt.cli)
-			assert.NoError(t, err)
+			client, err := api.NewGraphQLClient(api.ClientOptions{
+				Host:      "foo",
+				AuthToken: "bar",
+				Transport: &mockTransport{respBody: respBody},
+			})
+			require.NoError(t, err)
 
-			var listOpts *ListOptions
-			cmd := NewCmdList(
-				nil, nil, nil,
-				func(opts *ListOptions) error {
-					listOpts = opts
-					return nil
-				},
-			)
-			cmd.SetArgs(argv)
-			cmd.SetIn(&bytes.Buffer{})
-			cmd.SetOut(&bytes.Buffer{})
-			cmd.SetErr(&bytes.Buffer{})
+			ios := &mockTerminal{isTTY: tt.tty, colorEnabled: tt.colorEnabled, width: 999, height: 999}
+			opts := &ListOptions{
+				Client:   client,
+				IOs:      ios,
+				Prompter: &prompter.PrompterMock{},
+				Username: "johndoe",
+				Fields:   tt.fields,
+				Compact:  true,
+			}
 
-			_, err = cmd.ExecuteC()
-			if tt.wantErr != "" {
-				require.Equal(t, err.Error(), tt.wantErr)
+			require.NoError(t, listRun(context.Background(), opts))
+			if tt.fields != nil {
+				assert.NotContains(t, ios.stdout.String(), "\x1b")
 				return
 			}
-			require.NoError(t, err)
-
-			require.Equal(t, tt.wants.Username, listOpts.Username)
+			assert.Equal(t, tt.wantStdout, ios.stdout.String())
 		})
 	}
 }
 
-func Test_listRun(t *testing.T) {
-	defaultHTTPStubs := func(t *testing.T, mt *mockTransport) {
-		mt.respBody = `
-				{
-					"data": {
-						"user": {
-							"sponsors": {
-								"edges": [
-									{
-										"node": {
-											"login": "foo",
-											"name": "Foo"
-										}
-									},
-									{
-										"node": {
-											"login": "bar",
-											"name": "Bar"
-										}
-									}
-								]
-							}
-						}
-					}
-				}`
+func Test_listRun_output(t *testing.T) {
+	respBody := `{"data":{"repositoryOwner":{"sponsors":{"edges":[{"node":{"__typename":"User","login":"foo","name":"Foo"}},{"node":{"__typename":"User","login":"bar","name":"Bar"}}],"pageInfo":{"hasNextPage":false},"totalCount":2}}}}`
+
+	tests := []struct {
+		name       string
+		tty        bool
+		fields     []string
+		quiet      bool
+		output     string
+		respBody   string
+		existing   string
+		wantFile   string
+		wantStderr string
+		wantErr    string
+	}{
+		{
+			name:       "table tty",
+			tty:        true,
+			wantFile:   "foo\nbar\n",
+			wantStderr: "wrote 2 sponsors to sponsors.out\n",
+		}, {
+			name:       "json tty",
+			tty:        true,
+			fields:     []string{"login"},
+			wantFile:   `[{"login":"foo"},{"login":"bar"}]` + "\n",
+			wantStderr: "wrote 2 sponsors to sponsors.out\n",
+		}, {
+			name:     "json tty, quiet",
+			tty:      true,
+			quiet:    true,
+			fields:   []string{"login"},
+			wantFile: `[{"login":"foo"},{"login":"bar"}]` + "\n",
+		}, {
+			name:     "json no-tty",
+			tty:      false,
+			fields:   []string{"login"},
+			wantFile: `[{"login":"foo"},{"login":"bar"}]` + "\n",
+		}, {
+			name:    "failure missing directory",
+			tty:     true,
+			output:  "missing/sponsors.json",
+			wantErr: "failed to create output file",
+		}, {
+			name:     "replaces existing file",
+			tty:      false,
+			fields:   []string{"login"},
+			existing: "old",
+			wantFile: `[{"login":"foo"},{"login":"bar"}]` + "\n",
+		}, {
+			name:     "failure leaves existing file untouched",
+			tty:      true,
+			respBody: `{"data":null,"errors":[{"message":"some gql error"}]}`,
+			existing: "old",
+			wantFile: "old",
+			wantErr:  "GraphQL: some gql error",
+		}, {
+			name:     "failure creates no file",
+			tty:      true,
+			respBody: `{"data":null,"errors":[{"message":"some gql error"}]}`,
+			wantErr:  "GraphQL: some gql error",
+		},
 	}
 
-	emptyRespHTTPStubs := func(t *testing.T, mt *mockTransport) {
-		mt.respBody = `
-				{
-					"data": {
-						"user": {
-							"sponsors": {
-								"edges": []
-							}
-						}
-					}
-				}`
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := api.NewGraphQLClient(api.ClientOptions{
+				Host:      "foo",
+				AuthToken: "bar",
+				Transport: &mockTransport{respBody: cmp.Or(tt.respBody, respBody)},
+			})
+			require.NoError(t, err)
+
+			output := tt.output
+			if output == "" {
+				output = "sponsors.out"
+			}
+			t.Chdir(t.TempDir())
+			if tt.existing != "" {
+				require.NoError(t, os.WriteFile(output, []byte(tt.existing), 0o600))
+			}
+
+			ios := &mockTerminal{isTTY: tt.tty, width: 999, height: 999}
+			opts := &ListOptions{
+				Client:   client,
+				IOs:      ios,
+				Prompter: &prompter.PrompterMock{},
+				Username: "johndoe",
+				Fields:   tt.fields,
+				Output:   output,
+				Quiet:    tt.quiet,
+			}
+
+			err = listRun(context.Background(), opts)
+			entries, _ := os.ReadDir(".")
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				if tt.existing == "" {
+					assert.Empty(t, entries)
+					return
+				}
+			} else {
+				require.NoError(t, err)
+			}
+			require.Len(t, entries, 1)
+
+			b, err := os.ReadFile(output)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantFile, string(b))
+			if tt.wantErr != "" {
+				return
+			}
+			assert.Empty(t, ios.stdout.String())
+			assert.Equal(t, tt.wantStderr, ios.stderr.String())
+		})
 	}
+}
 
+func Test_listSponsors(t *testing.T) {
 	tests := []struct {
-		name          string
-		tty           bool
-		opts          *ListOptions
-		httpStubs     func(*testing.T, *mockTransport)
-		prompterStubs func(*testing.T, *prompter.PrompterMock)
-		wantStdout    []string
-		wantStderr    string
-		wantErr       string
+		name        string
+		limit       uint
+		sort        string
+		order       string
+		respBodies  []string
+		wantLogins  []string
+		wantTotal   int
+		wantAfters  []string
+		wantFirsts  []int
+		wantOrderBy string
+		wantErr     string
+		wantQueries int
 	}{
 		{
-			name: "normal tty",
-			tty:  true,
-			opts: &ListOptions{
-				Username: "johndoe",
-			},
-			httpStubs: defaultHTTPStubs,
-			wantStdout: []string{
-				"SPONSOR",
-				"foo",
-				"bar",
-			},
+			name:        "empty",
+			respBodies:  []string{sponsorsPage(5, "", false)},
+			wantLogins:  []string{},
+			wantTotal:   5,
+			wantAfters:  []string{""},
+			wantFirsts:  []int{100},
+			wantQueries: 1,
 		}, {
-			name:      "normal tty, no-username",
-			tty:       true,
-			opts:      &ListOptions{},
-			httpStubs: defaultHTTPStubs,
-			prompterStubs: func(t *testing.T, pm *prompter.PrompterMock) {
-				pm.RegisterInput("Which user do you want to target?", func(_, def string) (string, error) {
-					assert.Empty(t, def)
-					return "johndoe", nil
-				})
-			},
-			wantStdout: []string{
-				"SPONSOR",
-				"foo",
-				"bar",
-			},
+			name:        "single page",
+			respBodies:  []string{sponsorsPage(5, "c1", false, userEdges("bar", "foo")...)},
+			wantLogins:  []string{"bar", "foo"},
+			wantTotal:   5,
+			wantAfters:  []string{""},
+			wantFirsts:  []int{100},
+			wantQueries: 1,
 		}, {
-			name: "normal no-tty",
-			tty:  false,
-			opts: &ListOptions{
-				Username: "johndoe",
-			},
-			httpStubs: defaultHTTPStubs,
-			wantStdout: []string{
-				"foo",
-				"bar",
+			name: "multiple pages",
+			respBodies: []string{
+				sponsorsPage(5, "c1", true, userEdges("a", "b")...),
+				sponsorsPage(5, "c2", true, userEdges("c", "d")...),
+				sponsorsPage(5, "c3", false, userEdges("e")...),
 			},
+			wantLogins:  []string{"a", "b", "c", "d", "e"},
+			wantTotal:   5,
+			wantAfters:  []string{"", "c1", "c2"},
+			wantFirsts:  []int{100, 100, 100},
+			wantQueries: 3,
 		}, {
-			name: "normal json",
-			tty:  false,
-			opts: &ListOptions{
-				Username: "johndoe",
-				Fields:   []string{"login"},
+			name:  "limit stops pagination",
+			limit: 2,
+			respBodies: []string{
+				sponsorsPage(5, "c1", true, userEdges("a", "b")...),
+				sponsorsPage(5, "c2", false, userEdges("c")...),
 			},
-			httpStubs:  defaultHTTPStubs,
-			wantStdout: []string{"[{\"login\":\"foo\"},{\"login\":\"bar\"}]"},
+			wantLogins:  []string{"a", "b"},
+			wantTotal:   5,
+			wantAfters:  []string{""},
+			wantFirsts:  []int{2},
+			wantQueries: 1,
 		}, {
-			name: "normal json all fields",
-			tty:  false,
-			opts: &ListOptions{
-				Username: "johndoe",
-				Fields:   listFields,
+			name:  "limit spanning pages",
+			limit: 101,
+			respBodies: []string{
+				sponsorsPage(5, "c1", true, userEdges("a", "b")...),
+				sponsorsPage(5, "c2", false, userEdges("c")...),
 			},
-			httpStubs:  defaultHTTPStubs,
-			wantStdout: []string{"[{\"login\":\"foo\",\"name\":\"Foo\"},{\"login\":\"bar\",\"name\":\"Bar\"}]"},
+			wantLogins:  []string{"a", "b", "c"},
+			wantTotal:   5,
+			wantAfters:  []string{"", "c1"},
+			wantFirsts:  []int{100, 99},
+			wantQueries: 2,
 		}, {
-			name: "failure tty, prompt error",
-			tty:  true,
-			opts: &ListOptions{},
-			prompterStubs: func(t *testing.T, pm *prompter.PrompterMock) {
-				pm.RegisterInput("Which user do you want to target?", func(_, def string) (string, error) {
-					assert.Empty(t, def)
-					return "", errors.New("prompt error")
-				})
+			name: "organization sponsorable",
+			respBodies: []string{`
+				{
+					"data": {
+						"repositoryOwner": {
+							"sponsors": {
+								"edges": [
+									{"node": {"__typename": "Organization", "login": "some-org", "name": "Some Org"}},
+									{"node": {"__typename": "User", "login": "someone", "name": "Someone"}}
+								],
+								"totalCount": 2,
+								"pageInfo": {"endCursor": "c1", "hasNextPage": false}
+							}
+						}
+					}
+				}`,
 			},
-			wantErr: "prompt error",
-		}, {
-			name:    "failure no-tty, no-username",
-			tty:     false,
-			opts:    &ListOptions{},
-			wantErr: "username not provided",
+			wantLogins:  []string{"some-org", "someone"},
+			wantTotal:   2,
+			wantAfters:  []string{""},
+			wantFirsts:  []int{100},
+			wantQueries: 1,
 		}, {
-			name: "normal tty, no sponsor",
-			tty:  true,
-			opts: &ListOptions{
-				Username: "johndoe",
+			name: "unknown account",
+			respBodies: []string{
+				`{"data":{"repositoryOwner":null}}`,
 			},
-			httpStubs:  emptyRespHTTPStubs,
-			wantStderr: "no sponsor found\n",
+			wantErr: "no such user: \"johndoe\"",
 		}, {
-			name: "normal no-tty, no sponsor",
-			tty:  false,
-			opts: &ListOptions{
-				Username: "johndoe",
-			},
-			httpStubs: emptyRespHTTPStubs,
+			name:        "relevance descending",
+			sort:        "relevance",
+			order:       "desc",
+			respBodies:  []string{sponsorsPage(5, "c1", false, userEdges("foo", "bar")...)},
+			wantLogins:  []string{"foo", "bar"},
+			wantTotal:   5,
+			wantAfters:  []string{""},
+			wantFirsts:  []int{100},
+			wantOrderBy: `{"field":"RELEVANCE","direction":"DESC"}`,
+			wantQueries: 1,
 		}, {
-			name: "api error",
-			tty:  true,
-			opts: &ListOptions{
-				Username: "johndoe",
-			},
-			httpStubs: func(_ *testing.T, mt *mockTransport) {
-				mt.respBody = `{"data":{}, "errors": [{"message": "some gql error"}]}`
+			name:        "created falls back to login",
+			sort:        "created",
+			order:       "desc",
+			respBodies:  []string{sponsorsPage(5, "c1", false, userEdges("foo", "bar")...)},
+			wantLogins:  []string{"foo", "bar"},
+			wantTotal:   5,
+			wantAfters:  []string{""},
+			wantFirsts:  []int{100},
+			wantOrderBy: `{"field":"LOGIN","direction":"DESC"}`,
+			wantQueries: 1,
+		}, {
+			name: "error on later page",
+			respBodies: []string{
+				sponsorsPage(5, "c1", true, userEdges("a")...),
+				`{"data":{}, "errors": [{"message": "some gql error"}]}`,
 			},
-			wantErr: "GraphQL: some gql error",
+			wantErr: "failed to list sponsors for \"johndoe\": GraphQL: some gql error",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockTransport := &mockTransport{}
+			mockTransport := &mockTransport{respBodies: tt.respBodies}
 			client, err := api.NewGraphQLClient(api.ClientOptions{
 				Host:      "foo",
 				AuthToken: "bar",
@@ -241,54 +3162,54 @@ func Test_listRun(t *testing.T) {
 			})
 			require.NoError(t, err)
 
-			pm := &prompter.PrompterMock{}
-			if tt.prompterStubs != nil {
-				tt.prompterStubs(t, pm)
-			}
-			tt.opts.Prompter = pm
-
-			ios := &mockTerminal{
-				width:  999,
-				height: 999,
-			}
-			ios.isTTY = tt.tty
-
-			tt.opts.IOs = ios
-			tt.opts.Client = client
-
-			if tt.httpStubs != nil {
-				tt.httpStubs(t, mockTransport)
-			}
-
-			err = listRun(tt.opts)
+			sponsors, total, err := listSponsors(context.Background(), client, "johndoe", tt.limit, sponsorOrder(tt.sort, tt.order), 0, 0, io.Discard)
 			if tt.wantErr != "" {
 				require.EqualError(t, err, tt.wantErr)
 				return
 			}
 			require.NoError(t, err)
 
-			expectedStdout := ""
-			if len(tt.wantStdout) > 0 {
-				expectedStdout = fmt.Sprintf("%s\n", strings.Join(tt.wantStdout, "\n"))
+			logins := make([]string, 0, len(sponsors))
+			for _, s := range sponsors {
+				logins = append(logins, s.Login)
+			}
+			assert.Equal(t, tt.wantLogins, logins)
+			assert.Equal(t, tt.wantTotal, total)
+
+			require.Len(t, mockTransport.reqBodies, tt.wantQueries)
+			for i, body := range mockTransport.reqBodies {
+				var req struct {
+					Variables struct {
+						After   *string         `json:"after"`
+						First   int             `json:"first"`
+						OrderBy json.RawMessage `json:"orderBy"`
+					} `json:"variables"`
+				}
+				require.NoError(t, json.Unmarshal([]byte(body), &req))
+
+				after := ""
+				if req.Variables.After != nil {
+					after = *req.Variables.After
+				}
+				assert.Equal(t, tt.wantAfters[i], after)
+				assert.Equal(t, tt.wantFirsts[i], req.Variables.First)
+
+				wantOrderBy := tt.wantOrderBy
+				if wantOrderBy == "" {
+					wantOrderBy = `{"field":"LOGIN","direction":"ASC"}`
+				}
+				assert.JSONEq(t, wantOrderBy, string(req.Variables.OrderBy))
 			}
-			assert.Equal(t, expectedStdout, ios.stdout.String())
-			assert.Equal(t, tt.wantStderr, ios.stderr.String())
 		})
 	}
 }
 
-type mockTransport struct {
-	respBody       string
-	respStatusCode int
-}
+// blockingTransport never responds, until the request context is done.
+type blockingTransport struct{}
 
-func (t *mockTransport) RoundTrip(r *http.Request) (*http.Response, error) {
-	rec := httptest.NewRecorder()
-	if t.respStatusCode != 0 {
-		rec.WriteHeader(t.respStatusCode)
-	}
-	_, _ = rec.WriteString(t.respBody)
-	return rec.Result(), nil
+func (blockingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	<-r.Context().Done()
+	return nil, r.Context().Err()
 }
 
 type mockTerminal struct {
@@ -298,6 +3219,8 @@ type mockTerminal struct {
 	isTTY  bool
 	width  int
 	height int
+
+	colorEnabled bool
 }
 
 func (m *mockTerminal) In() io.Reader {
@@ -316,6 +3239,19 @@ func (m *mockTerminal) IsTerminalOutput() bool {
 	return m.isTTY
 }
 
+func (m *mockTerminal) IsColorEnabled() bool {
+	return m.colorEnabled
+}
+
 func (m *mockTerminal) Size() (int, int, error) {
 	return m.width, m.height, nil
 }
+
+type mockBrowser struct {
+	urls []string
+}
+
+func (m *mockBrowser) Browse(url string) error {
+	m.urls = append(m.urls, url)
+	return nil
+}
@@ -1,11 +1,17 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
+	"os/signal"
 
 	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/auth"
+	"github.com/cli/go-gh/v2/pkg/browser"
 	"github.com/cli/go-gh/v2/pkg/prompter"
 	"github.com/cli/go-gh/v2/pkg/term"
 	"github.com/spf13/cobra"
@@ -16,6 +22,7 @@ type Terminal interface {
 	Out() io.Writer
 	ErrOut() io.Writer
 	IsTerminalOutput() bool
+	IsColorEnabled() bool
 	Size() (int, int, error)
 }
 
@@ -23,11 +30,48 @@ type Prompter interface {
 	Input(prompt, defaultValue string) (string, error)
 }
 
+type Browser interface {
+	Browse(url string) error
+}
+
+// newGraphQLClient creates a GraphQL client for the given host, falling back
+// to the default host resolution (e.g. GH_HOST) when hostname is empty. The
+// client retries queries made with a withMaxRetries context, and caches the
+// responses of queries made with a withCache context.
+func newGraphQLClient(hostname string) (*api.GraphQLClient, error) {
+	return api.NewGraphQLClient(api.ClientOptions{
+		Host: hostname,
+		Transport: &cacheTransport{
+			base: &retryTransport{base: http.DefaultTransport},
+			dir:  cacheDir(),
+		},
+	})
+}
+
+// clientFunc returns the GraphQL client of the commands. It is created on the
+// first call, once the --hostname flag is parsed.
+type clientFunc func() (*api.GraphQLClient, error)
+
 func compose() (*cobra.Command, error) {
-	client, err := api.DefaultGraphQLClient()
-	api.NewGraphQLClient(api.ClientOptions{})
-	if err != nil {
-		return nil, err
+	return composeWithClient(newGraphQLClient)
+}
+
+// composeWithClient composes the root command, creating its GraphQL client
+// with the given factory, e.g. newGraphQLClient. The factory is called once,
+// when a command first needs the client, with the --hostname flag, which is
+// empty if unset.
+func composeWithClient(newClient func(hostname string) (*api.GraphQLClient, error)) (*cobra.Command, error) {
+	var hostname string
+	var gqlClient *api.GraphQLClient
+	client := func() (*api.GraphQLClient, error) {
+		if gqlClient == nil {
+			c, err := newClient(hostname)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create GraphQL client: %w", err)
+			}
+			gqlClient = c
+		}
+		return gqlClient, nil
 	}
 
 	ios := term.FromEnv()
@@ -42,22 +86,128 @@ func compose() (*cobra.Command, error) {
 		}
 	}
 
+	br := browser.New("", ios.Out(), ios.ErrOut())
+
 	rootCmd := &cobra.Command{
 		Use:   "sponsors <subcommand> [flags]",
 		Short: "Manage sponsors",
+		Long: `Manage sponsors.
+
+Exit status:
+  0  success
+  1  negative result, e.g. an empty list with --exit-status, or a failed check
+  2  error
+  3  account not found`,
+		// main prints the error once, without the usage.
+		SilenceErrors: true,
+		SilenceUsage:  true,
 	}
+	rootCmd.PersistentFlags().StringVar(&hostname, "hostname", "", "The GitHub host to use (e.g. a GitHub Enterprise Server instance)")
+	rootCmd.PersistentFlags().Bool("quiet", false, "Suppress informational messages, like empty result notices and file write confirmations")
 
-	rootCmd.AddCommand(NewCmdList(client, ios, pr, nil))
+	rootCmd.AddCommand(NewCmdList(client, ios, pr, br, nil))
+	rootCmd.AddCommand(NewCmdSponsoring(client, ios, pr, br, nil))
+	rootCmd.AddCommand(NewCmdCount(client, ios, pr, nil))
+	rootCmd.AddCommand(NewCmdTiers(client, ios, pr, nil))
+	rootCmd.AddCommand(NewCmdGoal(client, ios, pr, nil))
+	rootCmd.AddCommand(NewCmdActivity(client, ios, pr, nil))
+	rootCmd.AddCommand(NewCmdCheck(client, ios, nil))
+	rootCmd.AddCommand(NewCmdTop(client, ios, pr, nil))
+	rootCmd.AddCommand(NewCmdExport(client, ios, pr, nil))
+	rootCmd.AddCommand(NewCmdBreakdown(client, ios, pr, nil))
+	rootCmd.AddCommand(NewCmdDiff(ios, nil))
 
 	return rootCmd, nil
 }
 
+// isQuiet reports whether the global --quiet flag is set. It is false for
+// commands run on their own, e.g. in tests.
+func isQuiet(cmd *cobra.Command) bool {
+	quiet, _ := cmd.Flags().GetBool("quiet")
+	return quiet
+}
+
+// webHost returns the host of the web pages opened with --web, either the one
+// set with the global --hostname flag, or the default one, e.g. from GH_HOST.
+func webHost(cmd *cobra.Command) string {
+	if hostname, _ := cmd.Flags().GetString("hostname"); hostname != "" {
+		return hostname
+	}
+	host, _ := auth.DefaultHost()
+	return host
+}
+
+// webURL returns the URL of the given path on the given host, defaulting to
+// github.com.
+func webURL(host, path string) string {
+	if host == "" {
+		host = "github.com"
+	}
+	return "https://" + host + "/" + path
+}
+
+// errSilent is returned by commands with a negative result, like an empty
+// list with --exit-status, that is reported by the exit status alone.
+var errSilent = withExitStatus(errors.New("silent error"), exitNegative)
+
+// exitStatusError is a command error exiting the process with its own status.
+type exitStatusError struct {
+	err    error
+	status int
+}
+
+// withExitStatus returns an error wrapping err that makes the process exit
+// with the given status.
+func withExitStatus(err error, status int) error {
+	return &exitStatusError{err: err, status: status}
+}
+
+func (e *exitStatusError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitStatusError) Unwrap() error {
+	return e.err
+}
+
+// Exit statuses, as documented in the help of the root command. Like grep,
+// negative results are told apart from errors, and so are accounts that do not
+// exist, so that scripts can handle them.
+const (
+	exitNegative     = 1
+	exitError        = 2
+	exitUserNotFound = 3
+)
+
+// exitCode returns the process exit status for the given command error. Errors
+// made with withExitStatus exit with their own status.
+func exitCode(err error) int {
+	var statusErr *exitStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.status
+	}
+	if errors.Is(err, ErrUserNotFound) {
+		return exitUserNotFound
+	}
+	return exitError
+}
+
 func main() {
 	rc, err := compose()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "composition failed: %s\n", err)
+		os.Exit(exitError)
 	}
-	if err := rc.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
+
+	// Interrupting cancels the command's context, so requests in flight are
+	// cancelled instead of leaving the command hanging.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := rc.ExecuteContext(ctx); err != nil {
+		if !errors.Is(err, errSilent) {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		os.Exit(exitCode(err))
 	}
 }
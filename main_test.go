@@ -0,0 +1,241 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_compose(t *testing.T) {
+	t.Setenv("GH_CONFIG_DIR", t.TempDir())
+	t.Setenv("GH_HOST", "")
+	t.Setenv("GH_TOKEN", "some-token")
+
+	cmd, err := compose()
+	require.NoError(t, err)
+	require.NotNil(t, cmd)
+
+	names := []string{}
+	for _, c := range cmd.Commands() {
+		names = append(names, c.Name())
+	}
+	assert.Subset(t, names, []string{"list", "sponsoring", "count", "tiers", "goal", "activity", "check", "top", "export", "breakdown", "diff"})
+}
+
+func Test_composeWithClient_failure(t *testing.T) {
+	t.Setenv("GH_CONFIG_DIR", t.TempDir())
+
+	cmd, err := composeWithClient(func(string) (*api.GraphQLClient, error) {
+		return nil, errors.New("no token")
+	})
+	require.NoError(t, err)
+
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	cmd.SetArgs([]string{"count", "johndoe"})
+	cmd.SetOut(stdout)
+	cmd.SetErr(stderr)
+	require.EqualError(t, cmd.Execute(), "failed to create GraphQL client: no token")
+	// main prints the error, so cobra prints neither it nor the usage.
+	assert.Empty(t, stdout.String())
+	assert.Empty(t, stderr.String())
+}
+
+func Test_compose_enterpriseTokenOnly(t *testing.T) {
+	t.Setenv("GH_CONFIG_DIR", t.TempDir())
+	t.Setenv("GH_HOST", "")
+	t.Setenv("GH_TOKEN", "")
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GH_ENTERPRISE_TOKEN", "some-enterprise-token")
+
+	stdout, err := os.Create(filepath.Join(t.TempDir(), "stdout"))
+	require.NoError(t, err)
+	defer stdout.Close()
+	origStdout := os.Stdout
+	os.Stdout = stdout
+	t.Cleanup(func() { os.Stdout = origStdout })
+
+	var hostnames []string
+	cmd, err := composeWithClient(func(hostname string) (*api.GraphQLClient, error) {
+		hostnames = append(hostnames, hostname)
+		return newGraphQLClient(hostname)
+	})
+	require.NoError(t, err)
+
+	// Off a terminal, --web prints the URL without querying the API.
+	cmd.SetArgs([]string{"--hostname", "ghe.example.com", "list", "johndoe", "--web"})
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, []string{"ghe.example.com"}, hostnames)
+
+	out, err := os.ReadFile(stdout.Name())
+	require.NoError(t, err)
+	assert.Equal(t, "https://ghe.example.com/sponsors/johndoe\n", string(out))
+}
+
+func Test_composeWithClient_list(t *testing.T) {
+	t.Setenv("GH_CONFIG_DIR", t.TempDir())
+	t.Chdir(t.TempDir())
+
+	mockTransport := &mockTransport{
+		respBody: `{"data":{"repositoryOwner":{"sponsors":{"edges":[{"node":{"__typename":"User","login":"foo"}}],"totalCount":1}}}}`,
+	}
+	var hostnames []string
+	cmd, err := composeWithClient(func(hostname string) (*api.GraphQLClient, error) {
+		hostnames = append(hostnames, hostname)
+		return api.NewGraphQLClient(api.ClientOptions{
+			Host:      "foo",
+			AuthToken: "bar",
+			Transport: mockTransport,
+		})
+	})
+	require.NoError(t, err)
+
+	cmd.SetArgs([]string{"list", "johndoe", "--json", "login", "--output", "sponsors.json", "--hostname", "github.example.com"})
+	require.NoError(t, cmd.Execute())
+
+	assert.Equal(t, []string{"github.example.com"}, hostnames)
+	require.Len(t, mockTransport.reqBodies, 1)
+	assert.Contains(t, mockTransport.reqBodies[0], `"login":"johndoe"`)
+
+	out, err := os.ReadFile("sponsors.json")
+	require.NoError(t, err)
+	assert.Equal(t, `[{"login":"foo"}]`+"\n", string(out))
+}
+
+func Test_compose_hostname(t *testing.T) {
+	t.Setenv("GH_CONFIG_DIR", t.TempDir())
+	t.Setenv("GH_HOST", "")
+	t.Setenv("GH_TOKEN", "some-token")
+	t.Setenv("GH_ENTERPRISE_TOKEN", "some-enterprise-token")
+
+	cmd, err := compose()
+	require.NoError(t, err)
+
+	flag := cmd.PersistentFlags().Lookup("hostname")
+	require.NotNil(t, flag)
+	assert.Empty(t, flag.DefValue)
+}
+
+func Test_isQuiet(t *testing.T) {
+	t.Setenv("GH_CONFIG_DIR", t.TempDir())
+	t.Setenv("GH_HOST", "")
+	t.Setenv("GH_TOKEN", "some-token")
+
+	for _, quiet := range []bool{false, true} {
+		cmd, err := compose()
+		require.NoError(t, err)
+
+		var got *bool
+		sub := &cobra.Command{
+			Use: "sub",
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				q := isQuiet(cmd)
+				got = &q
+				return nil
+			},
+		}
+		cmd.AddCommand(sub)
+
+		args := []string{"sub"}
+		if quiet {
+			args = append(args, "--quiet")
+		}
+		cmd.SetArgs(args)
+		require.NoError(t, cmd.Execute())
+		require.NotNil(t, got)
+		assert.Equal(t, quiet, *got)
+	}
+
+	assert.False(t, isQuiet(&cobra.Command{}))
+}
+
+func Test_webHost(t *testing.T) {
+	t.Setenv("GH_CONFIG_DIR", t.TempDir())
+	t.Setenv("GH_TOKEN", "some-token")
+	t.Setenv("GH_ENTERPRISE_TOKEN", "some-enterprise-token")
+
+	tests := []struct {
+		name   string
+		ghHost string
+		args   []string
+		want   string
+	}{
+		{
+			name: "default",
+			want: "github.com",
+		}, {
+			name:   "GH_HOST",
+			ghHost: "ghe.example.com",
+			want:   "ghe.example.com",
+		}, {
+			name:   "hostname flag",
+			ghHost: "ghe.example.com",
+			args:   []string{"--hostname", "other.example.com"},
+			want:   "other.example.com",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("GH_HOST", tt.ghHost)
+
+			cmd, err := compose()
+			require.NoError(t, err)
+
+			var got string
+			cmd.AddCommand(&cobra.Command{
+				Use: "sub",
+				RunE: func(cmd *cobra.Command, _ []string) error {
+					got = webHost(cmd)
+					return nil
+				},
+			})
+			cmd.SetArgs(append([]string{"sub"}, tt.args...))
+			require.NoError(t, cmd.Execute())
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_webURL(t *testing.T) {
+	assert.Equal(t, "https://github.com/sponsors/johndoe", webURL("", "sponsors/johndoe"))
+	assert.Equal(t, "https://ghe.example.com/johndoe", webURL("ghe.example.com", "johndoe"))
+}
+
+func Test_newGraphQLClient(t *testing.T) {
+	t.Setenv("GH_CONFIG_DIR", t.TempDir())
+	t.Setenv("GH_HOST", "")
+	t.Setenv("GH_TOKEN", "some-token")
+	t.Setenv("GH_ENTERPRISE_TOKEN", "some-enterprise-token")
+
+	client, err := newGraphQLClient("")
+	require.NoError(t, err)
+	assert.NotNil(t, client)
+
+	client, err = newGraphQLClient("github.example.com")
+	require.NoError(t, err)
+	assert.NotNil(t, client)
+}
+
+func Test_exitCode(t *testing.T) {
+	assert.Equal(t, 2, exitCode(errors.New("some error")))
+	assert.Equal(t, 3, exitCode(fmt.Errorf("%w: johndoe", ErrUserNotFound)))
+	assert.Equal(t, 1, exitCode(errSilent))
+	assert.Equal(t, 1, exitCode(fmt.Errorf("failed: %w", errSilent)))
+	assert.Equal(t, 4, exitCode(withExitStatus(errors.New("some error"), 4)))
+	assert.Equal(t, 4, exitCode(withExitStatus(fmt.Errorf("%w: johndoe", ErrUserNotFound), 4)))
+}
+
+func Test_withExitStatus(t *testing.T) {
+	inner := errors.New("some error")
+	err := withExitStatus(inner, 4)
+	assert.EqualError(t, err, "some error")
+	assert.ErrorIs(t, err, inner)
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// defaultMaxRetries is the default number of times a failed query is retried
+// on rate limit and server errors.
+const defaultMaxRetries = 3
+
+// retryBaseDelay is the delay before the first retry of a server error. It is
+// doubled for each subsequent retry.
+const retryBaseDelay = time.Second
+
+// retryMaxDelay is the longest delay before a retry. Requests that would have to
+// wait longer, e.g. until a rate limit resets, are not retried.
+const retryMaxDelay = time.Minute
+
+// sleep waits between retries, returning early with the context error if the
+// context is done first. It is replaced in tests.
+var sleep = func(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// retryOptions control how retryTransport handles a request.
+type retryOptions struct {
+	// MaxRetries is the number of times a failed request is retried.
+	MaxRetries int
+	// ErrOut receives a notice before waiting for a rate limit to reset.
+	ErrOut io.Writer
+}
+
+type retryOptionsKey struct{}
+
+// withMaxRetries returns a context making retryTransport retry failed requests
+// up to n times, writing a notice to errOut before waiting for a rate limit to
+// reset.
+func withMaxRetries(ctx context.Context, n int, errOut io.Writer) context.Context {
+	return context.WithValue(ctx, retryOptionsKey{}, retryOptions{MaxRetries: n, ErrOut: errOut})
+}
+
+// retryTransport retries requests failing with a rate limit or server error,
+// up to the number of times set in the request context with withMaxRetries.
+// Requests are not retried by default, nor when the wait would exceed
+// retryMaxDelay. Requests still rate limited after the last retry fail with a
+// rateLimitError.
+type retryTransport struct {
+	base http.RoundTripper
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	opts, _ := req.Context().Value(retryOptionsKey{}).(retryOptions)
+
+	for attempt := 0; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if err != nil {
+			return resp, err
+		}
+		if attempt >= opts.MaxRetries {
+			return checkRateLimit(resp)
+		}
+
+		delay, ok := retryDelay(resp, attempt)
+		if !ok || delay > retryMaxDelay || (req.Body != nil && req.GetBody == nil) {
+			return checkRateLimit(resp)
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		if isRateLimited(resp) && delay > 0 && opts.ErrOut != nil {
+			fmt.Fprintf(opts.ErrOut, "rate limited; waiting until %s to retry\n", time.Now().Add(delay).UTC().Format("15:04:05 MST"))
+		}
+		if err := sleep(req.Context(), delay); err != nil {
+			return nil, err
+		}
+
+		if req.Body != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+// retryDelay returns how long to wait before retrying a request that got the
+// given response, or false if the response is not worth retrying. Rate limit
+// errors wait as long as the Retry-After or X-RateLimit-Reset headers say, and
+// server errors back off exponentially, up to retryMaxDelay.
+func retryDelay(resp *http.Response, attempt int) (time.Duration, bool) {
+	rateLimited := isRateLimited(resp)
+	if !rateLimited && resp.StatusCode < 500 {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		return time.Duration(secs) * time.Second, true
+	}
+	if rateLimited {
+		if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			return max(time.Until(time.Unix(reset, 0)), 0), true
+		}
+	}
+	return min(retryBaseDelay<<min(attempt, 16), retryMaxDelay), true
+}
+
+// isRateLimited reports whether the response denies the request with a 403 or
+// 429 status code because of the rate limits.
+func isRateLimited(resp *http.Response) bool {
+	return (resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests) &&
+		(resp.Header.Get("Retry-After") != "" || resp.Header.Get("X-RateLimit-Remaining") == "0")
+}
+
+// rateLimitError is the error of a request denied by the API rate limits.
+type rateLimitError struct {
+	// Reset is when the rate limit resets, or zero if unknown.
+	Reset time.Time
+}
+
+func (e *rateLimitError) Error() string {
+	if e.Reset.IsZero() {
+		return "rate limited; try again later"
+	}
+	return fmt.Sprintf("rate limited; resets at %s", e.Reset.UTC().Format("15:04 MST"))
+}
+
+// checkRateLimit returns a rateLimitError instead of the given response if it
+// denies the request because of the rate limits, either with a 403 or 429
+// status code, or with a RATE_LIMITED GraphQL error.
+func checkRateLimit(resp *http.Response) (*http.Response, error) {
+	retryAfter := resp.Header.Get("Retry-After")
+	exhausted := resp.Header.Get("X-RateLimit-Remaining") == "0"
+
+	limited := false
+	switch resp.StatusCode {
+	case http.StatusForbidden, http.StatusTooManyRequests:
+		limited = retryAfter != "" || exhausted
+	case http.StatusOK:
+		if !exhausted {
+			break
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		limited = bytes.Contains(body, []byte(`"RATE_LIMITED"`))
+	}
+	if !limited {
+		return resp, nil
+	}
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	rlErr := &rateLimitError{}
+	if secs, err := strconv.Atoi(retryAfter); err == nil {
+		rlErr.Reset = time.Now().Add(time.Duration(secs) * time.Second)
+	} else if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		rlErr.Reset = time.Unix(reset, 0)
+	}
+	return nil, rlErr
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_listSponsors_retry(t *testing.T) {
+	okBody := `{"data":{"repositoryOwner":{"sponsors":{"edges":[{"node":{"__typename":"User","login":"foo","name":""}}],"totalCount":1}}}}`
+
+	tests := []struct {
+		name            string
+		maxRetries      int
+		respStatusCodes []int
+		respHeaders     []http.Header
+		wantDelays      []time.Duration
+		wantStderr      string
+		wantErr         string
+	}{
+		{
+			name:            "server errors back off exponentially",
+			maxRetries:      3,
+			respStatusCodes: []int{502, 503, 200},
+			wantDelays:      []time.Duration{time.Second, 2 * time.Second},
+		}, {
+			name:            "rate limit honors Retry-After",
+			maxRetries:      3,
+			respStatusCodes: []int{403, 200},
+			respHeaders:     []http.Header{{"Retry-After": {"7"}}},
+			wantDelays:      []time.Duration{7 * time.Second},
+			wantStderr:      "rate limited; waiting until ",
+		}, {
+			name:            "rate limit beyond the maximum delay",
+			maxRetries:      3,
+			respStatusCodes: []int{429, 200},
+			respHeaders:     []http.Header{{"Retry-After": {"120"}}},
+			wantErr:         "rate limited; resets at ",
+		}, {
+			name:            "server error backoff is capped",
+			maxRetries:      7,
+			respStatusCodes: []int{500, 500, 500, 500, 500, 500, 500, 200},
+			wantDelays: []time.Duration{
+				time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second,
+				16 * time.Second, 32 * time.Second, time.Minute,
+			},
+		}, {
+			name:            "rate limit honors X-RateLimit-Reset",
+			maxRetries:      3,
+			respStatusCodes: []int{403, 200},
+			respHeaders:     []http.Header{{"X-Ratelimit-Remaining": {"0"}, "X-Ratelimit-Reset": {"0"}}},
+			wantDelays:      []time.Duration{0},
+		}, {
+			name:            "retries exhausted",
+			maxRetries:      2,
+			respStatusCodes: []int{500, 500, 500, 200},
+			wantDelays:      []time.Duration{time.Second, 2 * time.Second},
+			wantErr:         "non-200 OK status code: 500 Internal Server Error",
+		}, {
+			name:            "no retries",
+			maxRetries:      0,
+			respStatusCodes: []int{500, 200},
+			wantErr:         "non-200 OK status code: 500 Internal Server Error",
+		}, {
+			name:            "forbidden without rate limit",
+			maxRetries:      3,
+			respStatusCodes: []int{403, 200},
+			wantErr:         "non-200 OK status code: 403 Forbidden",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var delays []time.Duration
+			origSleep := sleep
+			sleep = func(_ context.Context, d time.Duration) error {
+				delays = append(delays, d)
+				return nil
+			}
+			t.Cleanup(func() { sleep = origSleep })
+
+			mockTransport := &mockTransport{
+				respBody:        okBody,
+				respStatusCodes: tt.respStatusCodes,
+				respHeaders:     tt.respHeaders,
+			}
+			client, err := api.NewGraphQLClient(api.ClientOptions{
+				Host:      "github.com",
+				AuthToken: "bar",
+				Transport: &retryTransport{base: mockTransport},
+			})
+			require.NoError(t, err)
+
+			stderr := &bytes.Buffer{}
+			sponsors, _, err := listSponsors(context.Background(), client, "johndoe", 0, sponsorOrder("login", "asc"), 0, tt.maxRetries, stderr)
+			assert.Equal(t, tt.wantDelays, delays)
+			if tt.wantStderr != "" {
+				assert.Contains(t, stderr.String(), tt.wantStderr)
+			} else {
+				assert.Empty(t, stderr.String())
+			}
+			require.Len(t, mockTransport.reqBodies, len(tt.wantDelays)+1)
+			for _, body := range mockTransport.reqBodies {
+				assert.Equal(t, mockTransport.reqBodies[0], body)
+			}
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, sponsors, 1)
+		})
+	}
+}
+
+func Test_listSponsors_rateLimit(t *testing.T) {
+	tests := []struct {
+		name           string
+		respStatusCode int
+		respBody       string
+		respHeader     http.Header
+		wantErr        string
+		wantRateLimit  bool
+	}{
+		{
+			name:           "rate limit status",
+			respStatusCode: 403,
+			respHeader:     http.Header{"X-Ratelimit-Remaining": {"0"}, "X-Ratelimit-Reset": {"1700000000"}},
+			wantErr:        "failed to list sponsors for \"johndoe\": rate limited; resets at 22:13 UTC",
+			wantRateLimit:  true,
+		}, {
+			name:           "rate limit status without reset",
+			respStatusCode: 429,
+			respHeader:     http.Header{"X-Ratelimit-Remaining": {"0"}},
+			wantErr:        "failed to list sponsors for \"johndoe\": rate limited; try again later",
+			wantRateLimit:  true,
+		}, {
+			name:          "graphql rate limit error",
+			respBody:      `{"data":null,"errors":[{"type":"RATE_LIMITED","message":"API rate limit exceeded"}]}`,
+			respHeader:    http.Header{"X-Ratelimit-Remaining": {"0"}, "X-Ratelimit-Reset": {"1700000000"}},
+			wantErr:       "failed to list sponsors for \"johndoe\": rate limited; resets at 22:13 UTC",
+			wantRateLimit: true,
+		}, {
+			name:       "graphql error with exhausted rate limit",
+			respBody:   `{"data":null,"errors":[{"message":"some gql error"}]}`,
+			respHeader: http.Header{"X-Ratelimit-Remaining": {"0"}, "X-Ratelimit-Reset": {"1700000000"}},
+			wantErr:    "failed to list sponsors for \"johndoe\": GraphQL: some gql error",
+		}, {
+			name:           "forbidden without rate limit",
+			respStatusCode: 403,
+			wantErr:        "failed to list sponsors for \"johndoe\": non-200 OK status code: 403 Forbidden body: \"\"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTransport := &mockTransport{
+				respBody:       tt.respBody,
+				respStatusCode: tt.respStatusCode,
+				respHeaders:    []http.Header{tt.respHeader},
+			}
+			client, err := api.NewGraphQLClient(api.ClientOptions{
+				Host:      "github.com",
+				AuthToken: "bar",
+				Transport: &retryTransport{base: mockTransport},
+			})
+			require.NoError(t, err)
+
+			_, _, err = listSponsors(context.Background(), client, "johndoe", 0, sponsorOrder("login", "asc"), 0, 0, io.Discard)
+			require.EqualError(t, err, tt.wantErr)
+
+			var rlErr *rateLimitError
+			assert.Equal(t, tt.wantRateLimit, errors.As(err, &rlErr))
+		})
+	}
+}
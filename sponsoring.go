@@ -0,0 +1,233 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/shurcooL/githubv4"
+	"github.com/spf13/cobra"
+)
+
+type SponsoringOptions struct {
+	Client   *api.GraphQLClient
+	IOs      Terminal
+	Prompter Prompter
+	Browser  Browser
+
+	Username  string
+	FieldsRaw string
+	Fields    []string
+	Pretty    bool
+	Compact   bool
+	Limit     int
+	All       bool
+	Web       bool
+	Quiet     bool
+	Host      string
+}
+
+func NewCmdSponsoring(
+	client clientFunc,
+	ios Terminal,
+	prompter Prompter,
+	browser Browser,
+	runF func(*SponsoringOptions) error,
+) *cobra.Command {
+	opts := &SponsoringOptions{
+		IOs:      ios,
+		Prompter: prompter,
+		Browser:  browser,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "sponsoring [<user>]",
+		Short: "List sponsored accounts",
+		Long: `List users and organizations sponsored by a given user or organization.
+
+The amount and monthlyPriceInCents fields hold the monthly sponsorship amount in
+US dollars and cents, respectively. They are zero for one-time sponsorships, and
+for sponsorships not visible to the viewer.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 1 {
+				return errors.New("too many arguments")
+			} else if len(args) == 1 {
+				opts.Username = args[0]
+			}
+
+			if err := validateLimit(opts.Limit); err != nil {
+				return err
+			}
+
+			fields, err := parseFields(opts.FieldsRaw)
+			if err != nil {
+				return err
+			}
+			opts.Fields = fields
+
+			opts.Quiet = isQuiet(cmd)
+			opts.Host = webHost(cmd)
+
+			if runF != nil {
+				return runF(opts)
+			}
+
+			c, err := client()
+			if err != nil {
+				return err
+			}
+			opts.Client = c
+
+			return sponsoringRun(opts)
+		},
+	}
+
+	// We can't use StringSliceVar method since it supports multiple assignments
+	// like: --json a,b --json c
+	cmd.Flags().StringVar(&opts.FieldsRaw, "json", "", "JSON fields")
+	cmd.Flags().BoolVar(&opts.Pretty, "pretty", false, "Pretty-print JSON output, even when not on a terminal")
+	cmd.Flags().BoolVar(&opts.Compact, "compact", false, "Print compact JSON output, even on a terminal")
+	cmd.MarkFlagsMutuallyExclusive("pretty", "compact")
+	cmd.Flags().IntVarP(&opts.Limit, "limit", "L", 0, fmt.Sprintf("Maximum number of accounts to fetch (default %d, or $%s)", defaultListLimit, listLimitEnv))
+	cmd.Flags().BoolVar(&opts.All, "all", false, "Fetch all sponsored accounts, following pagination")
+	cmd.Flags().BoolVarP(&opts.Web, "web", "w", false, "Open the profile page in the browser")
+
+	return cmd
+}
+
+func sponsoringRun(opts *SponsoringOptions) error {
+	username, err := resolveUsername(opts.Client, opts.IOs, opts.Prompter, opts.Username)
+	if err != nil {
+		return err
+	}
+
+	if opts.Web {
+		return openInBrowser(opts.IOs, opts.Browser, webURL(opts.Host, username))
+	}
+
+	sponsoring, _, err := listSponsoring(opts.Client, username, effectiveLimit(opts.Limit, opts.All, opts.IOs.ErrOut()), opts.IOs.ErrOut())
+	if err != nil {
+		return err
+	}
+
+	return printSponsors(opts.IOs, sponsoring, opts.Fields, nil, prettyJSON(opts.IOs, opts.Pretty, opts.Compact), false, opts.Quiet, "SPONSORING", "not sponsoring anyone")
+}
+
+// sponsoringNode holds the fields queried for each sponsored account, whether
+// it is a user or an organization.
+type sponsoringNode struct {
+	Login      githubv4.String
+	Name       githubv4.String
+	AvatarURL  githubv4.String
+	URL        githubv4.URI
+	DatabaseID githubv4.Int
+}
+
+func (n sponsoringNode) toSponsor() sponsor {
+	return sponsor{
+		Login:      string(n.Login),
+		Name:       string(n.Name),
+		AvatarURL:  string(n.AvatarURL),
+		URL:        uriString(n.URL),
+		DatabaseID: int(n.DatabaseID),
+	}
+}
+
+// listSponsoring fetches the accounts sponsored by the given user or
+// organization, following the connection's pagination until it is exhausted,
+// along with their sponsorships by it. A non-zero limit caps the number of
+// returned accounts. The total number of sponsored accounts is returned along
+// with the fetched ones. Warnings are written to errOut.
+func listSponsoring(client *api.GraphQLClient, username string, limit uint, errOut io.Writer) ([]sponsor, int, error) {
+	sponsoring, total, err := paginateSponsors(limit, errOut, func(first githubv4.Int, after *githubv4.String) (*sponsorConnection[sponsoringNode], error) {
+		var query struct {
+			RepositoryOwner *struct {
+				Sponsorable struct {
+					Sponsoring sponsorConnection[sponsoringNode] `graphql:"sponsoring(first: $first, after: $after, orderBy: { direction: ASC, field: LOGIN })"`
+				} `graphql:"... on Sponsorable"`
+			} `graphql:"repositoryOwner(login: $login)"`
+		}
+
+		variables := map[string]any{
+			"login": githubv4.String(username),
+			"first": first,
+			"after": after,
+		}
+
+		if err := client.Query("SponsoringList", &query, variables); err != nil {
+			return nil, fmt.Errorf("failed to list accounts sponsored by %q: %w", username, translateQueryError(err))
+		}
+		if query.RepositoryOwner == nil {
+			return nil, userNotFoundError(username)
+		}
+		return &query.RepositoryOwner.Sponsorable.Sponsoring, nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// The sponsoring connection only tells the sponsorships of the viewer, so
+	// the ones of the given account are queried for each page of accounts.
+	for page := range slices.Chunk(sponsoring, sponsorsPageSize) {
+		if err := applySponsorshipsAsSponsor(client, username, page); err != nil {
+			return nil, 0, err
+		}
+	}
+	return sponsoring, total, nil
+}
+
+// applySponsorshipsAsSponsor sets the sponsorship fields of the given accounts
+// to those of their sponsorships by the given user or organization, leaving
+// them empty for sponsorships not visible to the viewer.
+func applySponsorshipsAsSponsor(client *api.GraphQLClient, username string, sponsored []sponsor) error {
+	logins := make([]githubv4.String, 0, len(sponsored))
+	for _, s := range sponsored {
+		logins = append(logins, githubv4.String(s.Login))
+	}
+
+	var query struct {
+		RepositoryOwner *struct {
+			Sponsorable struct {
+				SponsorshipsAsSponsor struct {
+					Nodes []struct {
+						sponsorship
+						Sponsorable struct {
+							User struct{ Login githubv4.String } `graphql:"... on User"`
+							Org  struct{ Login githubv4.String } `graphql:"... on Organization"`
+						}
+					}
+				} `graphql:"sponsorshipsAsSponsor(first: $first, maintainerLogins: $logins)"`
+			} `graphql:"... on Sponsorable"`
+		} `graphql:"repositoryOwner(login: $login)"`
+	}
+
+	variables := map[string]any{
+		"login":  githubv4.String(username),
+		"first":  githubv4.Int(len(logins)),
+		"logins": logins,
+	}
+
+	if err := client.Query("SponsoringSponsorships", &query, variables); err != nil {
+		return fmt.Errorf("failed to list sponsorships of %q: %w", username, translateQueryError(err))
+	}
+	if query.RepositoryOwner == nil {
+		return userNotFoundError(username)
+	}
+
+	byLogin := make(map[string]*sponsorship)
+	for i, n := range query.RepositoryOwner.Sponsorable.SponsorshipsAsSponsor.Nodes {
+		login := n.Sponsorable.User.Login
+		if login == "" {
+			login = n.Sponsorable.Org.Login
+		}
+		byLogin[string(login)] = &query.RepositoryOwner.Sponsorable.SponsorshipsAsSponsor.Nodes[i].sponsorship
+	}
+	for i := range sponsored {
+		if sp, ok := byLogin[sponsored[i].Login]; ok {
+			sp.applyTo(&sponsored[i])
+		}
+	}
+	return nil
+}
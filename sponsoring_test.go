@@ -0,0 +1,429 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/prompter"
+	"github.com/google/shlex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCmdSponsoring(t *testing.T) {
+	tests := []struct {
+		name    string
+		cli     string
+		wants   SponsoringOptions
+		wantErr string
+	}{
+		{
+			name: "no arg",
+			cli:  "",
+			wants: SponsoringOptions{
+				Username: "",
+			},
+		}, {
+			name: "normal",
+			cli:  "johndoe",
+			wants: SponsoringOptions{
+				Username: "johndoe",
+			},
+		}, {
+			name: "normal json",
+			cli:  "--json name,login johndoe",
+			wants: SponsoringOptions{
+				Username: "johndoe",
+				Fields:   []string{"name", "login"},
+			},
+		}, {
+			name: "json pretty",
+			cli:  "--json login --pretty johndoe",
+			wants: SponsoringOptions{
+				Username: "johndoe",
+				Fields:   []string{"login"},
+				Pretty:   true,
+			},
+		}, {
+			name:    "failure pretty and compact",
+			cli:     "--json login --pretty --compact johndoe",
+			wantErr: "if any flags in the group [pretty compact] are set none of the others can be; [compact pretty] were all set",
+		}, {
+			name: "limit and all",
+			cli:  "--all -L 5 johndoe",
+			wants: SponsoringOptions{
+				Username: "johndoe",
+				Limit:    5,
+				All:      true,
+			},
+		}, {
+			name:    "failure too many arguments",
+			cli:     "johndoe janedoe",
+			wantErr: "too many arguments",
+		}, {
+			name:    "failure limit above max",
+			cli:     "--limit 101 johndoe",
+			wantErr: "invalid limit: 101 (must not exceed 100)",
+		}, {
+			name:    "failure json",
+			cli:     "--json blah johndoe",
+			wantErr: "unknown JSON field: \"blah\" (available fields: login, name, tier, amount, monthlyPriceInCents, createdAt, type, avatarUrl, privacy, bio, company, location, isOneTime, url, databaseId, websiteUrl, twitterUsername, socials)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			argv, err := shlex.Split(tt.cli)
+			assert.NoError(t, err)
+
+			var sponsoringOpts *SponsoringOptions
+			cmd := NewCmdSponsoring(
+				nil, nil, nil, nil,
+				func(opts *SponsoringOptions) error {
+					sponsoringOpts = opts
+					return nil
+				},
+			)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			_, err = cmd.ExecuteC()
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			require.Equal(t, tt.wants.Username, sponsoringOpts.Username)
+			require.Equal(t, tt.wants.Fields, sponsoringOpts.Fields)
+			require.Equal(t, tt.wants.Limit, sponsoringOpts.Limit)
+			require.Equal(t, tt.wants.All, sponsoringOpts.All)
+			require.Equal(t, tt.wants.Pretty, sponsoringOpts.Pretty)
+			require.Equal(t, tt.wants.Compact, sponsoringOpts.Compact)
+		})
+	}
+}
+
+func Test_listSponsoring_sponsorships(t *testing.T) {
+	mockTransport := &mockTransport{
+		respBodies: []string{
+			`{"data":{"repositoryOwner":{"sponsoring":{"edges":[
+				{"node":{"__typename":"User","login":"foo"}},
+				{"node":{"__typename":"Organization","login":"acme"}}
+			],"totalCount":2}}}}`,
+			`{"data":{"repositoryOwner":{"sponsorshipsAsSponsor":{"nodes":[
+				{"isOneTimePayment":true,"tier":{"name":"$10 one time","monthlyPriceInDollars":10,"monthlyPriceInCents":1000},"sponsorable":{"login":"acme"}}
+			]}}}}`,
+		},
+	}
+	client, err := api.NewGraphQLClient(api.ClientOptions{
+		Host:      "foo",
+		AuthToken: "bar",
+		Transport: mockTransport,
+	})
+	require.NoError(t, err)
+
+	sponsoring, total, err := listSponsoring(client, "johndoe", 0, io.Discard)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+
+	// The sponsorships of the given account are queried for the fetched
+	// accounts, not the ones of the viewer.
+	require.Len(t, mockTransport.reqBodies, 2)
+	assert.NotContains(t, mockTransport.reqBodies[0], "sponsorshipForViewer")
+	assert.Contains(t, mockTransport.reqBodies[1], `"logins":["foo","acme"]`)
+	assert.Contains(t, mockTransport.reqBodies[1], `"login":"johndoe"`)
+
+	require.Len(t, sponsoring, 2)
+	assert.Empty(t, sponsoring[0].Tier)
+	assert.Equal(t, "$10 one time", sponsoring[1].Tier)
+	assert.True(t, sponsoring[1].IsOneTime)
+	assert.Equal(t, 10, sponsoring[1].OneTimeAmountInDollars)
+}
+
+func Test_sponsoringRun(t *testing.T) {
+	defaultHTTPStubs := func(t *testing.T, mt *mockTransport) {
+		mt.respBodies = []string{`
+				{
+					"data": {
+						"repositoryOwner": {
+							"sponsoring": {
+								"edges": [
+									{
+										"node": {
+											"__typename": "User",
+											"login": "foo",
+											"name": "Foo"
+										}
+									},
+									{
+										"node": {
+											"__typename": "Organization",
+											"login": "bar-org",
+											"name": "Bar"
+										}
+									}
+								]
+							}
+						}
+					}
+				}`, `
+				{
+					"data": {
+						"repositoryOwner": {
+							"sponsorshipsAsSponsor": {
+								"nodes": [
+									{
+										"tier": {
+											"name": "$5 a month",
+											"monthlyPriceInDollars": 5,
+											"monthlyPriceInCents": 500
+										},
+										"sponsorable": {
+											"login": "foo"
+										}
+									}
+								]
+							}
+						}
+					}
+				}`,
+		}
+	}
+
+	emptyRespHTTPStubs := func(t *testing.T, mt *mockTransport) {
+		mt.respBody = `{"data":{"repositoryOwner":{"sponsoring":{"edges":[]}}}}`
+	}
+
+	tests := []struct {
+		name          string
+		tty           bool
+		opts          *SponsoringOptions
+		httpStubs     func(*testing.T, *mockTransport)
+		prompterStubs func(*testing.T, *prompter.PrompterMock)
+		wantStdout    []string
+		wantStderr    string
+		wantErr       string
+	}{
+		{
+			name: "normal tty",
+			tty:  true,
+			opts: &SponsoringOptions{
+				Username: "johndoe",
+			},
+			httpStubs: defaultHTTPStubs,
+			wantStdout: []string{
+				"SPONSORING  NAME  TIER        MONTHLY",
+				"foo         Foo   $5 a month  $5",
+				"bar-org     Bar               ",
+			},
+		}, {
+			name: "normal tty, no-username",
+			tty:  true,
+			opts: &SponsoringOptions{},
+			httpStubs: func(t *testing.T, mt *mockTransport) {
+				defaultHTTPStubs(t, mt)
+				mt.respBodies = append([]string{`{"data":{"viewer":{"login":""}}}`}, mt.respBodies...)
+			},
+			prompterStubs: func(t *testing.T, pm *prompter.PrompterMock) {
+				pm.RegisterInput("Which user do you want to target?", func(_, def string) (string, error) {
+					assert.Empty(t, def)
+					return "johndoe", nil
+				})
+			},
+			wantStdout: []string{
+				"SPONSORING  NAME  TIER        MONTHLY",
+				"foo         Foo   $5 a month  $5",
+				"bar-org     Bar               ",
+			},
+		}, {
+			name: "normal no-tty",
+			tty:  false,
+			opts: &SponsoringOptions{
+				Username: "johndoe",
+			},
+			httpStubs: defaultHTTPStubs,
+			wantStdout: []string{
+				"foo",
+				"bar-org",
+			},
+		}, {
+			name: "normal json",
+			tty:  false,
+			opts: &SponsoringOptions{
+				Username: "johndoe",
+				Fields:   []string{"login", "name"},
+			},
+			httpStubs:  defaultHTTPStubs,
+			wantStdout: []string{"[{\"login\":\"foo\",\"name\":\"Foo\"},{\"login\":\"bar-org\",\"name\":\"Bar\"}]"},
+		}, {
+			name: "json compact tty",
+			tty:  true,
+			opts: &SponsoringOptions{
+				Username: "johndoe",
+				Fields:   []string{"login"},
+				Compact:  true,
+			},
+			httpStubs:  defaultHTTPStubs,
+			wantStdout: []string{`[{"login":"foo"},{"login":"bar-org"}]`},
+		}, {
+			name: "json pretty no-tty",
+			tty:  false,
+			opts: &SponsoringOptions{
+				Username: "johndoe",
+				Fields:   []string{"login"},
+				Pretty:   true,
+			},
+			httpStubs: defaultHTTPStubs,
+			wantStdout: []string{
+				"[",
+				"  {",
+				`    "login": "foo"`,
+				"  },",
+				"  {",
+				`    "login": "bar-org"`,
+				"  }",
+				"]",
+			},
+		}, {
+			name: "failure tty, prompt error",
+			tty:  true,
+			opts: &SponsoringOptions{},
+			prompterStubs: func(t *testing.T, pm *prompter.PrompterMock) {
+				pm.RegisterInput("Which user do you want to target?", func(_, def string) (string, error) {
+					return "", errors.New("prompt error")
+				})
+			},
+			wantErr: "prompt error",
+		}, {
+			name:    "failure no-tty, no-username",
+			tty:     false,
+			opts:    &SponsoringOptions{},
+			wantErr: "username not provided",
+		}, {
+			name: "web tty",
+			tty:  true,
+			opts: &SponsoringOptions{
+				Username: "johndoe",
+				Web:      true,
+			},
+			wantStderr: "Opening https://github.com/johndoe in your browser.\n",
+		}, {
+			name: "web no-tty",
+			tty:  false,
+			opts: &SponsoringOptions{
+				Username: "johndoe",
+				Web:      true,
+			},
+			wantStdout: []string{"https://github.com/johndoe"},
+		}, {
+			name: "web enterprise host",
+			tty:  false,
+			opts: &SponsoringOptions{
+				Username: "johndoe",
+				Host:     "ghe.example.com",
+				Web:      true,
+			},
+			wantStdout: []string{"https://ghe.example.com/johndoe"},
+		}, {
+			name: "normal tty, not sponsoring",
+			tty:  true,
+			opts: &SponsoringOptions{
+				Username: "johndoe",
+			},
+			httpStubs:  emptyRespHTTPStubs,
+			wantStderr: "not sponsoring anyone\n",
+		}, {
+			name: "normal no-tty, not sponsoring",
+			tty:  false,
+			opts: &SponsoringOptions{
+				Username: "johndoe",
+			},
+			httpStubs: emptyRespHTTPStubs,
+		}, {
+			name: "failure unknown account",
+			tty:  true,
+			opts: &SponsoringOptions{
+				Username: "johndoe",
+			},
+			httpStubs: func(_ *testing.T, mt *mockTransport) {
+				mt.respBody = `{"data":{"repositoryOwner":null}}`
+			},
+			wantErr: "no such user: \"johndoe\"",
+		}, {
+			name: "api error",
+			tty:  true,
+			opts: &SponsoringOptions{
+				Username: "johndoe",
+			},
+			httpStubs: func(_ *testing.T, mt *mockTransport) {
+				mt.respBody = `{"data":{}, "errors": [{"message": "some gql error"}]}`
+			},
+			wantErr: "failed to list accounts sponsored by \"johndoe\": GraphQL: some gql error",
+		}, {
+			name: "sponsorships api error",
+			tty:  true,
+			opts: &SponsoringOptions{
+				Username: "johndoe",
+			},
+			httpStubs: func(t *testing.T, mt *mockTransport) {
+				defaultHTTPStubs(t, mt)
+				mt.respBodies[1] = `{"data":{}, "errors": [{"message": "some gql error"}]}`
+			},
+			wantErr: "failed to list sponsorships of \"johndoe\": GraphQL: some gql error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTransport := &mockTransport{}
+			client, err := api.NewGraphQLClient(api.ClientOptions{
+				Host:      "foo",
+				AuthToken: "bar",
+				Transport: mockTransport,
+			})
+			require.NoError(t, err)
+
+			pm := &prompter.PrompterMock{}
+			if tt.prompterStubs != nil {
+				tt.prompterStubs(t, pm)
+			}
+			tt.opts.Prompter = pm
+			tt.opts.Browser = &mockBrowser{}
+
+			ios := &mockTerminal{
+				width:  999,
+				height: 999,
+			}
+			ios.isTTY = tt.tty
+
+			tt.opts.IOs = ios
+			tt.opts.Client = client
+
+			if tt.httpStubs != nil {
+				tt.httpStubs(t, mockTransport)
+			}
+
+			err = sponsoringRun(tt.opts)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			expectedStdout := ""
+			if len(tt.wantStdout) > 0 {
+				expectedStdout = fmt.Sprintf("%s\n", strings.Join(tt.wantStdout, "\n"))
+			}
+			assert.Equal(t, expectedStdout, ios.stdout.String())
+			assert.Equal(t, tt.wantStderr, ios.stderr.String())
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/tableprinter"
+	"github.com/shurcooL/githubv4"
+	"github.com/spf13/cobra"
+)
+
+var tiersFields = []string{
+	"name",
+	"amount",
+	"monthlyPriceInCents",
+	"description",
+	"isOneTime",
+}
+
+type TiersOptions struct {
+	Client   *api.GraphQLClient
+	IOs      Terminal
+	Prompter Prompter
+
+	Username  string
+	FieldsRaw string
+	Fields    []string
+	Quiet     bool
+}
+
+func NewCmdTiers(
+	client clientFunc,
+	ios Terminal,
+	prompter Prompter,
+	runF func(*TiersOptions) error,
+) *cobra.Command {
+	opts := &TiersOptions{
+		IOs:      ios,
+		Prompter: prompter,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "tiers [<user>]",
+		Short: "List sponsorship tiers",
+		Long: `List sponsorship tiers offered by a given user or organization.
+
+The amount and monthlyPriceInCents fields hold the price of the tier in US
+dollars and cents, respectively, which is charged monthly unless isOneTime is
+true.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 1 {
+				return errors.New("too many arguments")
+			} else if len(args) == 1 {
+				opts.Username = args[0]
+			}
+
+			fields, err := parseFieldsOf(opts.FieldsRaw, tiersFields)
+			if err != nil {
+				return err
+			}
+			opts.Fields = fields
+
+			opts.Quiet = isQuiet(cmd)
+
+			if runF != nil {
+				return runF(opts)
+			}
+
+			c, err := client()
+			if err != nil {
+				return err
+			}
+			opts.Client = c
+
+			return tiersRun(opts)
+		},
+	}
+
+	// We can't use StringSliceVar method since it supports multiple assignments
+	// like: --json a,b --json c
+	cmd.Flags().StringVar(&opts.FieldsRaw, "json", "", "JSON fields")
+
+	return cmd
+}
+
+func tiersRun(opts *TiersOptions) error {
+	username, err := resolveUsername(opts.Client, opts.IOs, opts.Prompter, opts.Username)
+	if err != nil {
+		return err
+	}
+
+	tiers, err := listTiers(opts.Client, username)
+	if err != nil {
+		return err
+	}
+
+	if opts.Fields != nil {
+		data := make([]any, 0, len(tiers))
+		for _, tier := range tiers {
+			m := make(map[string]any, len(opts.Fields))
+			for _, f := range opts.Fields {
+				switch f {
+				case "name":
+					m["name"] = tier.Name
+				case "amount":
+					m["amount"] = tier.AmountInDollars
+				case "monthlyPriceInCents":
+					m["monthlyPriceInCents"] = tier.AmountInCents
+				case "description":
+					m["description"] = tier.Description
+				case "isOneTime":
+					m["isOneTime"] = tier.IsOneTime
+				}
+			}
+			data = append(data, m)
+		}
+
+		return printJSON(opts.IOs, data, opts.IOs.IsTerminalOutput())
+	}
+
+	if len(tiers) == 0 {
+		if opts.IOs.IsTerminalOutput() && !opts.Quiet {
+			fmt.Fprintln(opts.IOs.ErrOut(), "no sponsorship tiers found")
+		}
+		return nil
+	}
+
+	width, _, _ := opts.IOs.Size()
+	table := tableprinter.New(opts.IOs.Out(), opts.IOs.IsTerminalOutput(), width)
+	table.AddHeader([]string{"NAME", "MONTHLY", "TYPE"})
+	for _, tier := range tiers {
+		table.AddField(tier.Name)
+		table.AddField(formatDollars(tier.AmountInDollars))
+		if tier.IsOneTime {
+			table.AddField("one-time")
+		} else {
+			table.AddField("monthly")
+		}
+		table.EndRow()
+	}
+
+	return table.Render()
+}
+
+type tier struct {
+	Name            string
+	AmountInDollars int
+	AmountInCents   int
+	Description     string
+	IsOneTime       bool
+}
+
+// listTiers fetches the sponsorship tiers of the given user or organization.
+// It returns no tiers if the account has no GitHub Sponsors profile.
+func listTiers(client *api.GraphQLClient, username string) ([]tier, error) {
+	var query struct {
+		RepositoryOwner *struct {
+			Sponsorable struct {
+				SponsorsListing *struct {
+					Tiers struct {
+						Nodes []struct {
+							Name                  githubv4.String
+							MonthlyPriceInDollars githubv4.Int
+							MonthlyPriceInCents   githubv4.Int
+							Description           githubv4.String
+							IsOneTime             githubv4.Boolean
+						}
+					} `graphql:"tiers(first: 100)"`
+				}
+			} `graphql:"... on Sponsorable"`
+		} `graphql:"repositoryOwner(login: $login)"`
+	}
+
+	variables := map[string]any{
+		"login": githubv4.String(username),
+	}
+
+	if err := client.Query("SponsorTierList", &query, variables); err != nil {
+		return nil, fmt.Errorf("failed to list sponsorship tiers for %q: %w", username, translateQueryError(err))
+	}
+	if query.RepositoryOwner == nil {
+		return nil, userNotFoundError(username)
+	}
+
+	listing := query.RepositoryOwner.Sponsorable.SponsorsListing
+	if listing == nil {
+		return []tier{}, nil
+	}
+
+	result := make([]tier, 0, len(listing.Tiers.Nodes))
+	for _, node := range listing.Tiers.Nodes {
+		result = append(result, tier{
+			Name:            string(node.Name),
+			AmountInDollars: int(node.MonthlyPriceInDollars),
+			AmountInCents:   int(node.MonthlyPriceInCents),
+			Description:     string(node.Description),
+			IsOneTime:       bool(node.IsOneTime),
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,280 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/prompter"
+	"github.com/google/shlex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCmdTiers(t *testing.T) {
+	tests := []struct {
+		name    string
+		cli     string
+		wants   TiersOptions
+		wantErr string
+	}{
+		{
+			name: "no arg",
+			cli:  "",
+			wants: TiersOptions{
+				Username: "",
+			},
+		}, {
+			name: "normal",
+			cli:  "johndoe",
+			wants: TiersOptions{
+				Username: "johndoe",
+			},
+		}, {
+			name: "normal json",
+			cli:  "--json name,amount,description,isOneTime johndoe",
+			wants: TiersOptions{
+				Username: "johndoe",
+				Fields:   []string{"name", "amount", "description", "isOneTime"},
+			},
+		}, {
+			name: "json all",
+			cli:  "--json all johndoe",
+			wants: TiersOptions{
+				Username: "johndoe",
+				Fields:   []string{"name", "amount", "monthlyPriceInCents", "description", "isOneTime"},
+			},
+		}, {
+			name: "json trimmed and deduplicated",
+			cli:  "--json 'name, amount,name' johndoe",
+			wants: TiersOptions{
+				Username: "johndoe",
+				Fields:   []string{"name", "amount"},
+			},
+		}, {
+			name:    "failure too many arguments",
+			cli:     "johndoe janedoe",
+			wantErr: "too many arguments",
+		}, {
+			name:    "failure json",
+			cli:     "--json login johndoe",
+			wantErr: "unknown JSON field: \"login\" (available fields: name, amount, monthlyPriceInCents, description, isOneTime)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			argv, err := shlex.Split(tt.cli)
+			assert.NoError(t, err)
+
+			var tiersOpts *TiersOptions
+			cmd := NewCmdTiers(
+				nil, nil, nil,
+				func(opts *TiersOptions) error {
+					tiersOpts = opts
+					return nil
+				},
+			)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			_, err = cmd.ExecuteC()
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			require.Equal(t, tt.wants.Username, tiersOpts.Username)
+			require.Equal(t, tt.wants.Fields, tiersOpts.Fields)
+		})
+	}
+}
+
+func Test_tiersRun(t *testing.T) {
+	defaultHTTPStubs := func(t *testing.T, mt *mockTransport) {
+		mt.respBody = `
+				{
+					"data": {
+						"repositoryOwner": {
+							"sponsorsListing": {
+								"tiers": {
+									"nodes": [
+										{
+											"name": "$5 a month",
+											"monthlyPriceInDollars": 5,
+											"monthlyPriceInCents": 500,
+											"description": "Thank you!",
+											"isOneTime": false
+										},
+										{
+											"name": "$1,500 one time",
+											"monthlyPriceInDollars": 1500,
+											"monthlyPriceInCents": 150000,
+											"description": "Wow, thanks!",
+											"isOneTime": true
+										}
+									]
+								}
+							}
+						}
+					}
+				}`
+	}
+
+	tests := []struct {
+		name          string
+		tty           bool
+		opts          *TiersOptions
+		httpStubs     func(*testing.T, *mockTransport)
+		prompterStubs func(*testing.T, *prompter.PrompterMock)
+		wantStdout    []string
+		wantStderr    string
+		wantErr       string
+	}{
+		{
+			name: "normal tty",
+			tty:  true,
+			opts: &TiersOptions{
+				Username: "johndoe",
+			},
+			httpStubs: defaultHTTPStubs,
+			wantStdout: []string{
+				"NAME             MONTHLY  TYPE",
+				"$5 a month       $5       monthly",
+				"$1,500 one time  $1,500   one-time",
+			},
+		}, {
+			name:      "normal tty, no-username",
+			tty:       true,
+			opts:      &TiersOptions{},
+			httpStubs: defaultHTTPStubs,
+			prompterStubs: func(t *testing.T, pm *prompter.PrompterMock) {
+				pm.RegisterInput("Which user do you want to target?", func(_, def string) (string, error) {
+					assert.Empty(t, def)
+					return "johndoe", nil
+				})
+			},
+			wantStdout: []string{
+				"NAME             MONTHLY  TYPE",
+				"$5 a month       $5       monthly",
+				"$1,500 one time  $1,500   one-time",
+			},
+		}, {
+			name: "normal no-tty",
+			tty:  false,
+			opts: &TiersOptions{
+				Username: "johndoe",
+			},
+			httpStubs: defaultHTTPStubs,
+			wantStdout: []string{
+				"$5 a month\t$5\tmonthly",
+				"$1,500 one time\t$1,500\tone-time",
+			},
+		}, {
+			name: "normal json",
+			tty:  false,
+			opts: &TiersOptions{
+				Username: "johndoe",
+				Fields:   tiersFields,
+			},
+			httpStubs:  defaultHTTPStubs,
+			wantStdout: []string{`[{"amount":5,"description":"Thank you!","isOneTime":false,"monthlyPriceInCents":500,"name":"$5 a month"},{"amount":1500,"description":"Wow, thanks!","isOneTime":true,"monthlyPriceInCents":150000,"name":"$1,500 one time"}]`},
+		}, {
+			name: "normal tty, no sponsors listing",
+			tty:  true,
+			opts: &TiersOptions{
+				Username: "johndoe",
+			},
+			httpStubs: func(_ *testing.T, mt *mockTransport) {
+				mt.respBody = `{"data":{"repositoryOwner":{"sponsorsListing":null}}}`
+			},
+			wantStderr: "no sponsorship tiers found\n",
+		}, {
+			name: "failure unknown account",
+			tty:  true,
+			opts: &TiersOptions{
+				Username: "johndoe",
+			},
+			httpStubs: func(_ *testing.T, mt *mockTransport) {
+				mt.respBody = `{"data":{"repositoryOwner":null}}`
+			},
+			wantErr: "no such user: \"johndoe\"",
+		}, {
+			name: "failure tty, prompt error",
+			tty:  true,
+			opts: &TiersOptions{},
+			prompterStubs: func(t *testing.T, pm *prompter.PrompterMock) {
+				pm.RegisterInput("Which user do you want to target?", func(_, def string) (string, error) {
+					return "", errors.New("prompt error")
+				})
+			},
+			wantErr: "prompt error",
+		}, {
+			name:    "failure no-tty, no-username",
+			tty:     false,
+			opts:    &TiersOptions{},
+			wantErr: "username not provided",
+		}, {
+			name: "api error",
+			tty:  true,
+			opts: &TiersOptions{
+				Username: "johndoe",
+			},
+			httpStubs: func(_ *testing.T, mt *mockTransport) {
+				mt.respBody = `{"data":{}, "errors": [{"message": "some gql error"}]}`
+			},
+			wantErr: "failed to list sponsorship tiers for \"johndoe\": GraphQL: some gql error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTransport := &mockTransport{}
+			client, err := api.NewGraphQLClient(api.ClientOptions{
+				Host:      "foo",
+				AuthToken: "bar",
+				Transport: mockTransport,
+			})
+			require.NoError(t, err)
+
+			pm := &prompter.PrompterMock{}
+			if tt.prompterStubs != nil {
+				tt.prompterStubs(t, pm)
+			}
+			tt.opts.Prompter = pm
+
+			ios := &mockTerminal{
+				width:  999,
+				height: 999,
+			}
+			ios.isTTY = tt.tty
+
+			tt.opts.IOs = ios
+			tt.opts.Client = client
+
+			if tt.httpStubs != nil {
+				tt.httpStubs(t, mockTransport)
+			}
+
+			err = tiersRun(tt.opts)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			expectedStdout := ""
+			if len(tt.wantStdout) > 0 {
+				expectedStdout = fmt.Sprintf("%s\n", strings.Join(tt.wantStdout, "\n"))
+			}
+			assert.Equal(t, expectedStdout, ios.stdout.String())
+			assert.Equal(t, tt.wantStderr, ios.stderr.String())
+		})
+	}
+}
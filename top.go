@@ -0,0 +1,163 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/tableprinter"
+	"github.com/spf13/cobra"
+)
+
+// defaultTopCount is the default number of sponsors shown by the top command.
+const defaultTopCount = 10
+
+var topFields = []string{
+	"rank",
+	"login",
+	"amount",
+}
+
+type TopOptions struct {
+	Client   *api.GraphQLClient
+	IOs      Terminal
+	Prompter Prompter
+
+	Username  string
+	FieldsRaw string
+	Fields    []string
+	Count     int
+	Quiet     bool
+}
+
+func NewCmdTop(
+	client clientFunc,
+	ios Terminal,
+	prompter Prompter,
+	runF func(*TopOptions) error,
+) *cobra.Command {
+	opts := &TopOptions{
+		IOs:      ios,
+		Prompter: prompter,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "top [<user>]",
+		Short: "List the highest-value sponsors",
+		Long: `List the sponsors of a given user or organization paying the highest monthly
+amounts, highest first.
+
+Sponsorship amounts are only visible to the sponsored account and its admins.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 1 {
+				return errors.New("too many arguments")
+			} else if len(args) == 1 {
+				opts.Username = args[0]
+			}
+
+			if opts.Count <= 0 {
+				return fmt.Errorf("invalid count: %d (must be greater than zero)", opts.Count)
+			}
+
+			fields, err := parseFieldsOf(opts.FieldsRaw, topFields)
+			if err != nil {
+				return err
+			}
+			opts.Fields = fields
+
+			opts.Quiet = isQuiet(cmd)
+
+			if runF != nil {
+				return runF(opts)
+			}
+
+			c, err := client()
+			if err != nil {
+				return err
+			}
+			opts.Client = c
+
+			return topRun(cmd.Context(), opts)
+		},
+	}
+
+	// We can't use StringSliceVar method since it supports multiple assignments
+	// like: --json a,b --json c
+	cmd.Flags().StringVar(&opts.FieldsRaw, "json", "", "JSON fields")
+	cmd.Flags().IntVarP(&opts.Count, "count", "n", defaultTopCount, "Number of sponsors to show")
+
+	return cmd
+}
+
+func topRun(ctx context.Context, opts *TopOptions) error {
+	username, err := resolveUsername(opts.Client, opts.IOs, opts.Prompter, opts.Username)
+	if err != nil {
+		return err
+	}
+
+	sponsors, _, err := listSponsors(ctx, opts.Client, username, 0, sponsorOrder("login", "asc"), 0, defaultMaxRetries, opts.IOs.ErrOut())
+	if err != nil {
+		return err
+	}
+	sponsors = topSponsors(sponsors, opts.Count)
+
+	if opts.Fields != nil {
+		data := make([]any, 0, len(sponsors))
+		for i, s := range sponsors {
+			m := make(map[string]any, len(opts.Fields))
+			for _, f := range opts.Fields {
+				switch f {
+				case "rank":
+					m["rank"] = i + 1
+				case "login":
+					m["login"] = s.Login
+				case "amount":
+					m["amount"] = s.AmountInDollars
+				}
+			}
+			data = append(data, m)
+		}
+
+		return printJSON(opts.IOs, data, opts.IOs.IsTerminalOutput())
+	}
+
+	if len(sponsors) == 0 {
+		if opts.IOs.IsTerminalOutput() && !opts.Quiet {
+			fmt.Fprintln(opts.IOs.ErrOut(), "no sponsor found")
+		}
+		return nil
+	}
+
+	width, _, _ := opts.IOs.Size()
+	table := tableprinter.New(opts.IOs.Out(), opts.IOs.IsTerminalOutput(), width)
+	table.AddHeader([]string{"RANK", "SPONSOR", "MONTHLY"})
+	for i, s := range sponsors {
+		table.AddField(strconv.Itoa(i + 1))
+		table.AddField(s.Login)
+		table.AddField(formatDollars(s.AmountInDollars))
+		table.EndRow()
+	}
+
+	return table.Render()
+}
+
+// topSponsors returns the count sponsors with the highest monthly amounts,
+// highest first, breaking ties by login.
+func topSponsors(sponsors []sponsor, count int) []sponsor {
+	sorted := slices.Clone(sponsors)
+	slices.SortStableFunc(sorted, func(a, b sponsor) int {
+		if c := cmp.Compare(b.AmountInDollars, a.AmountInDollars); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Login, b.Login)
+	})
+	if len(sorted) > count {
+		sorted = sorted[:count]
+	}
+	return sorted
+}
@@ -0,0 +1,289 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/prompter"
+	"github.com/google/shlex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCmdTop(t *testing.T) {
+	tests := []struct {
+		name    string
+		cli     string
+		wants   TopOptions
+		wantErr string
+	}{
+		{
+			name: "no arg",
+			cli:  "",
+			wants: TopOptions{
+				Username: "",
+				Count:    defaultTopCount,
+			},
+		}, {
+			name: "normal",
+			cli:  "johndoe",
+			wants: TopOptions{
+				Username: "johndoe",
+				Count:    defaultTopCount,
+			},
+		}, {
+			name: "normal count",
+			cli:  "--count 3 johndoe",
+			wants: TopOptions{
+				Username: "johndoe",
+				Count:    3,
+			},
+		}, {
+			name: "normal json",
+			cli:  "--json rank,login,amount johndoe",
+			wants: TopOptions{
+				Username: "johndoe",
+				Count:    defaultTopCount,
+				Fields:   []string{"rank", "login", "amount"},
+			},
+		}, {
+			name: "json all",
+			cli:  "--json all johndoe",
+			wants: TopOptions{
+				Username: "johndoe",
+				Count:    defaultTopCount,
+				Fields:   []string{"rank", "login", "amount"},
+			},
+		}, {
+			name: "json trimmed and deduplicated",
+			cli:  "--json 'login,,amount, login' johndoe",
+			wants: TopOptions{
+				Username: "johndoe",
+				Count:    defaultTopCount,
+				Fields:   []string{"login", "amount"},
+			},
+		}, {
+			name:    "failure too many arguments",
+			cli:     "johndoe janedoe",
+			wantErr: "too many arguments",
+		}, {
+			name:    "failure count",
+			cli:     "--count 0 johndoe",
+			wantErr: "invalid count: 0 (must be greater than zero)",
+		}, {
+			name:    "failure json",
+			cli:     "--json name johndoe",
+			wantErr: "unknown JSON field: \"name\" (available fields: rank, login, amount)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			argv, err := shlex.Split(tt.cli)
+			assert.NoError(t, err)
+
+			var topOpts *TopOptions
+			cmd := NewCmdTop(
+				nil, nil, nil,
+				func(opts *TopOptions) error {
+					topOpts = opts
+					return nil
+				},
+			)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			_, err = cmd.ExecuteC()
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			require.Equal(t, tt.wants.Username, topOpts.Username)
+			require.Equal(t, tt.wants.Count, topOpts.Count)
+			require.Equal(t, tt.wants.Fields, topOpts.Fields)
+		})
+	}
+}
+
+func Test_topRun(t *testing.T) {
+	defaultHTTPStubs := func(t *testing.T, mt *mockTransport) {
+		node := func(login string, dollars int) string {
+			return userEdge(login, fmt.Sprintf(`"sponsorshipsAsSponsor":{"nodes":[{"tier":{"name":"","monthlyPriceInDollars":%d,"monthlyPriceInCents":%d}}]}`, dollars, dollars*100))
+		}
+		mt.respBody = sponsorsPage(4, "", false,
+			node("bar", 5),
+			node("foo", 100),
+			node("baz", 1500),
+			node("alice", 100),
+		)
+	}
+
+	tests := []struct {
+		name          string
+		tty           bool
+		opts          *TopOptions
+		httpStubs     func(*testing.T, *mockTransport)
+		prompterStubs func(*testing.T, *prompter.PrompterMock)
+		wantStdout    []string
+		wantStderr    string
+		wantErr       string
+	}{
+		{
+			name: "normal tty",
+			tty:  true,
+			opts: &TopOptions{
+				Username: "johndoe",
+				Count:    defaultTopCount,
+			},
+			httpStubs: defaultHTTPStubs,
+			wantStdout: []string{
+				"RANK  SPONSOR  MONTHLY",
+				"1     baz      $1,500",
+				"2     alice    $100",
+				"3     foo      $100",
+				"4     bar      $5",
+			},
+		}, {
+			name: "normal tty, count",
+			tty:  true,
+			opts: &TopOptions{
+				Username: "johndoe",
+				Count:    2,
+			},
+			httpStubs: defaultHTTPStubs,
+			wantStdout: []string{
+				"RANK  SPONSOR  MONTHLY",
+				"1     baz      $1,500",
+				"2     alice    $100",
+			},
+		}, {
+			name:      "normal tty, no-username",
+			tty:       true,
+			opts:      &TopOptions{Count: 1},
+			httpStubs: defaultHTTPStubs,
+			prompterStubs: func(t *testing.T, pm *prompter.PrompterMock) {
+				pm.RegisterInput("Which user do you want to target?", func(_, def string) (string, error) {
+					assert.Empty(t, def)
+					return "johndoe", nil
+				})
+			},
+			wantStdout: []string{
+				"RANK  SPONSOR  MONTHLY",
+				"1     baz      $1,500",
+			},
+		}, {
+			name: "normal no-tty",
+			tty:  false,
+			opts: &TopOptions{
+				Username: "johndoe",
+				Count:    2,
+			},
+			httpStubs: defaultHTTPStubs,
+			wantStdout: []string{
+				"1\tbaz\t$1,500",
+				"2\talice\t$100",
+			},
+		}, {
+			name: "normal json",
+			tty:  false,
+			opts: &TopOptions{
+				Username: "johndoe",
+				Count:    2,
+				Fields:   topFields,
+			},
+			httpStubs:  defaultHTTPStubs,
+			wantStdout: []string{`[{"amount":1500,"login":"baz","rank":1},{"amount":100,"login":"alice","rank":2}]`},
+		}, {
+			name: "normal tty, no sponsors",
+			tty:  true,
+			opts: &TopOptions{
+				Username: "johndoe",
+				Count:    defaultTopCount,
+			},
+			httpStubs: func(_ *testing.T, mt *mockTransport) {
+				mt.respBody = `{"data":{"repositoryOwner":{"sponsors":{"edges":[],"totalCount":0}}}}`
+			},
+			wantStderr: "no sponsor found\n",
+		}, {
+			name: "failure unknown account",
+			tty:  true,
+			opts: &TopOptions{
+				Username: "johndoe",
+				Count:    defaultTopCount,
+			},
+			httpStubs: func(_ *testing.T, mt *mockTransport) {
+				mt.respBody = `{"data":{"repositoryOwner":null}}`
+			},
+			wantErr: "no such user: \"johndoe\"",
+		}, {
+			name: "failure tty, prompt error",
+			tty:  true,
+			opts: &TopOptions{Count: defaultTopCount},
+			prompterStubs: func(t *testing.T, pm *prompter.PrompterMock) {
+				pm.RegisterInput("Which user do you want to target?", func(_, def string) (string, error) {
+					return "", errors.New("prompt error")
+				})
+			},
+			wantErr: "prompt error",
+		}, {
+			name:    "failure no-tty, no-username",
+			tty:     false,
+			opts:    &TopOptions{Count: defaultTopCount},
+			wantErr: "username not provided",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTransport := &mockTransport{}
+			client, err := api.NewGraphQLClient(api.ClientOptions{
+				Host:      "foo",
+				AuthToken: "bar",
+				Transport: mockTransport,
+			})
+			require.NoError(t, err)
+
+			pm := &prompter.PrompterMock{}
+			if tt.prompterStubs != nil {
+				tt.prompterStubs(t, pm)
+			}
+			tt.opts.Prompter = pm
+
+			ios := &mockTerminal{
+				width:  999,
+				height: 999,
+			}
+			ios.isTTY = tt.tty
+
+			tt.opts.IOs = ios
+			tt.opts.Client = client
+
+			if tt.httpStubs != nil {
+				tt.httpStubs(t, mockTransport)
+			}
+
+			err = topRun(context.Background(), tt.opts)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			expectedStdout := ""
+			if len(tt.wantStdout) > 0 {
+				expectedStdout = fmt.Sprintf("%s\n", strings.Join(tt.wantStdout, "\n"))
+			}
+			assert.Equal(t, expectedStdout, ios.stdout.String())
+			assert.Equal(t, tt.wantStderr, ios.stderr.String())
+		})
+	}
+}
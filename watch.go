@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// defaultWatchInterval is the default time between two polls of --watch.
+const defaultWatchInterval = time.Minute
+
+// clearScreen is the escape sequence that clears the terminal and moves the
+// cursor to its top-left corner.
+const clearScreen = "\x1b[H\x1b[2J"
+
+// listWatchRun lists the sponsors of the given user or organization every
+// interval until the context is done, notifying of the sponsors never seen
+// before. On a terminal, the table is redrawn on each poll. Otherwise, only
+// the logins of new sponsors are printed, one per line, e.g. to feed alerts.
+func listWatchRun(ctx context.Context, opts *ListOptions, username string) error {
+	// known holds the logins seen on all polls so far, so that a sponsor
+	// dropping out of a poll is not announced again when back. It is nil
+	// until the first poll, whose sponsors are not new.
+	var known map[string]bool
+	for {
+		sponsors, total, err := fetchWatchedSponsors(ctx, opts, username)
+		if err != nil {
+			if ctx.Err() != nil {
+				// Interrupted, e.g. with Ctrl+C.
+				return nil
+			}
+			return err
+		}
+
+		var added []string
+		if known != nil {
+			for _, s := range sponsors {
+				if !known[s.Login] {
+					added = append(added, s.Login)
+				}
+			}
+		} else {
+			known = make(map[string]bool, len(sponsors))
+		}
+		for _, s := range sponsors {
+			known[s.Login] = true
+		}
+
+		if opts.IOs.IsTerminalOutput() {
+			fmt.Fprint(opts.IOs.Out(), clearScreen)
+			if err := printSponsors(opts.IOs, sponsors, nil, opts.Columns, false, opts.NoHeader, opts.Quiet, "SPONSOR", "no sponsor found"); err != nil {
+				return err
+			}
+			if !opts.Quiet {
+				for _, login := range added {
+					fmt.Fprintf(opts.IOs.Out(), "🎉 new sponsor: %s\n", login)
+				}
+				fmt.Fprintf(opts.IOs.ErrOut(), "%s, updated at %s; press Ctrl+C to stop\n", showingHint(opts, len(sponsors), total), opts.Now().Format(time.TimeOnly))
+			}
+		} else {
+			for _, login := range added {
+				fmt.Fprintln(opts.IOs.Out(), login)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(opts.Interval):
+		}
+	}
+}
+
+// fetchWatchedSponsors fetches the sponsors for one poll of --watch, applying
+// --timeout to the poll rather than to the whole watch.
+func fetchWatchedSponsors(ctx context.Context, opts *ListOptions, username string) ([]sponsor, int, error) {
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+	return fetchSponsors(ctx, opts, username)
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cancelTransport cancels a context once it has served a number of requests.
+type cancelTransport struct {
+	base   http.RoundTripper
+	after  int
+	cancel context.CancelFunc
+}
+
+func (t *cancelTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(r)
+	t.after--
+	if t.after <= 0 {
+		t.cancel()
+	}
+	return resp, err
+}
+
+func Test_listWatchRun(t *testing.T) {
+
+	tests := []struct {
+		name       string
+		tty        bool
+		quiet      bool
+		respBodies []string
+		// requests is the number of requests after which the watch is
+		// interrupted, 2 if unset.
+		requests   int
+		wantStdout string
+		wantStderr string
+		wantErr    string
+	}{
+		{
+			name: "new sponsor",
+			tty:  true,
+			respBodies: []string{
+				`{"data":{"repositoryOwner":{"sponsors":{"edges":[{"node":{"__typename":"User","login":"foo"}}],"totalCount":1}}}}`,
+				`{"data":{"repositoryOwner":{"sponsors":{"edges":[{"node":{"__typename":"User","login":"bar"}},{"node":{"__typename":"User","login":"foo"}}],"totalCount":2}}}}`,
+			},
+			wantStdout: clearScreen + "SPONSOR\nfoo\n" +
+				clearScreen + "SPONSOR\nbar\nfoo\n🎉 new sponsor: bar\n",
+			wantStderr: "Showing 1 of 1 sponsors, updated at 10:00:00; press Ctrl+C to stop\n" +
+				"Showing 2 of 2 sponsors, updated at 10:00:00; press Ctrl+C to stop\n",
+		}, {
+			name:  "new sponsor quiet",
+			tty:   true,
+			quiet: true,
+			respBodies: []string{
+				`{"data":{"repositoryOwner":{"sponsors":{"edges":[{"node":{"__typename":"User","login":"foo"}}],"totalCount":1}}}}`,
+				`{"data":{"repositoryOwner":{"sponsors":{"edges":[{"node":{"__typename":"User","login":"bar"}},{"node":{"__typename":"User","login":"foo"}}],"totalCount":2}}}}`,
+			},
+			wantStdout: clearScreen + "SPONSOR\nfoo\n" +
+				clearScreen + "SPONSOR\nbar\nfoo\n",
+		}, {
+			name: "lost sponsor",
+			tty:  true,
+			respBodies: []string{
+				`{"data":{"repositoryOwner":{"sponsors":{"edges":[{"node":{"__typename":"User","login":"bar"}},{"node":{"__typename":"User","login":"foo"}}],"totalCount":2}}}}`,
+				`{"data":{"repositoryOwner":{"sponsors":{"edges":[{"node":{"__typename":"User","login":"foo"}}],"totalCount":1}}}}`,
+			},
+			wantStdout: clearScreen + "SPONSOR\nbar\nfoo\n" +
+				clearScreen + "SPONSOR\nfoo\n",
+			wantStderr: "Showing 2 of 2 sponsors, updated at 10:00:00; press Ctrl+C to stop\n" +
+				"Showing 1 of 1 sponsors, updated at 10:00:00; press Ctrl+C to stop\n",
+		}, {
+			name: "failure api error",
+			tty:  true,
+			respBodies: []string{
+				`{"data":{}, "errors": [{"message": "some gql error"}]}`,
+				`{"data":{}, "errors": [{"message": "some gql error"}]}`,
+			},
+			wantErr: "failed to list sponsors for \"johndoe\": GraphQL: some gql error",
+		}, {
+			name: "new sponsors no-tty",
+			respBodies: []string{
+				`{"data":{"repositoryOwner":{"sponsors":{"edges":[{"node":{"__typename":"User","login":"foo"}}],"totalCount":1}}}}`,
+				`{"data":{"repositoryOwner":{"sponsors":{"edges":[{"node":{"__typename":"User","login":"bar"}},{"node":{"__typename":"User","login":"baz"}},{"node":{"__typename":"User","login":"foo"}}],"totalCount":3}}}}`,
+			},
+			wantStdout: "bar\nbaz\n",
+		}, {
+			name: "returning sponsor no-tty",
+			respBodies: []string{
+				`{"data":{"repositoryOwner":{"sponsors":{"edges":[{"node":{"__typename":"User","login":"bar"}},{"node":{"__typename":"User","login":"foo"}}],"totalCount":2}}}}`,
+				`{"data":{"repositoryOwner":{"sponsors":{"edges":[{"node":{"__typename":"User","login":"foo"}}],"totalCount":1}}}}`,
+				`{"data":{"repositoryOwner":{"sponsors":{"edges":[{"node":{"__typename":"User","login":"bar"}},{"node":{"__typename":"User","login":"foo"}},{"node":{"__typename":"User","login":"qux"}}],"totalCount":3}}}}`,
+			},
+			requests:   3,
+			wantStdout: "qux\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			requests := tt.requests
+			if requests == 0 {
+				requests = 2
+			}
+
+			mockTransport := &mockTransport{respBodies: tt.respBodies}
+			client, err := api.NewGraphQLClient(api.ClientOptions{
+				Host:      "foo",
+				AuthToken: "bar",
+				Transport: &cancelTransport{base: mockTransport, after: requests, cancel: cancel},
+			})
+			require.NoError(t, err)
+
+			ios := &mockTerminal{isTTY: tt.tty, width: 999, height: 999}
+			opts := &ListOptions{
+				Client:   client,
+				IOs:      ios,
+				Sort:     "login",
+				Order:    "asc",
+				Columns:  []string{"login"},
+				Interval: time.Millisecond,
+				Quiet:    tt.quiet,
+				Now:      func() time.Time { return time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC) },
+			}
+
+			err = listWatchRun(ctx, opts, "johndoe")
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantStdout, ios.stdout.String())
+			assert.Equal(t, tt.wantStderr, ios.stderr.String())
+		})
+	}
+}